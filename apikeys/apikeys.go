@@ -0,0 +1,120 @@
+package apikeys
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Scopes that can be granted to an API key
+const (
+	ScopeRead   = "read"   // Public GET endpoints
+	ScopeIngest = "ingest" // Adding new APODs
+	ScopeAdmin  = "admin"  // Managing keys and other administrative endpoints
+)
+
+// ValidScopes lists every scope that can be granted
+var ValidScopes = []string{ScopeRead, ScopeIngest, ScopeAdmin}
+
+// Default quotas for new keys (0 means unlimited)
+const (
+	DefaultDailyQuota   int64 = 10000
+	DefaultMonthlyQuota int64 = 200000
+)
+
+// keyPrefix is prepended to every generated key so leaked keys are easy to recognise
+const keyPrefix = "av_"
+
+// APIKey represents an issued API key. Only the hash of the key is stored.
+// swagger:model APIKey
+type APIKey struct {
+	// MongoDB ID
+	// example: 665f1f77bcf86cd799439011
+	ID primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	// Human readable name of the key owner
+	// example: Mobile app
+	Name string `bson:"name" json:"name"`
+	// First characters of the key, to identify it without revealing it
+	// example: av_Xy12ab
+	Prefix string `bson:"prefix" json:"prefix"`
+	// SHA-256 hash of the key
+	Hash string `bson:"hash" json:"-"`
+	// Scopes granted to the key
+	// example: ["read"]
+	Scopes []string `bson:"scopes" json:"scopes"`
+	// Maximum number of requests per day (0 = unlimited)
+	// example: 10000
+	DailyQuota int64 `bson:"daily_quota" json:"daily_quota"`
+	// Maximum number of requests per month (0 = unlimited)
+	// example: 200000
+	MonthlyQuota int64 `bson:"monthly_quota" json:"monthly_quota"`
+	// Creation time
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	// Last rotation time
+	RotatedAt *time.Time `bson:"rotated_at,omitempty" json:"rotated_at,omitempty"`
+	// Revocation time (revoked keys are rejected)
+	RevokedAt *time.Time `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+}
+
+// HasScope checks whether the key was granted a scope (admin implies every scope)
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidScope checks whether a scope name is known
+func IsValidScope(scope string) bool {
+	for _, s := range ValidScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Generate creates a new random key, returning the plaintext key and its hash
+func Generate() (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("error generating API key: %v", err)
+	}
+	key := keyPrefix + base64.RawURLEncoding.EncodeToString(buf)
+	return key, Hash(key), nil
+}
+
+// Hash returns the hex-encoded SHA-256 hash of a key. Keys are long random strings,
+// so a fast hash is enough to make the stored values useless if leaked.
+func Hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Equal compares two secrets in constant time
+func Equal(a, b string) bool {
+	ha := sha256.Sum256([]byte(a))
+	hb := sha256.Sum256([]byte(b))
+	return subtle.ConstantTimeCompare(ha[:], hb[:]) == 1
+}
+
+// displayPrefix returns the visible part of a key
+func displayPrefix(key string) string {
+	if len(key) <= len(keyPrefix)+6 {
+		return key
+	}
+	return key[:len(keyPrefix)+6]
+}
+
+// Subject identifies the key in usage counters
+func (k *APIKey) Subject() string {
+	return "key:" + k.ID.Hex()
+}
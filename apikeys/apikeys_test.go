@@ -0,0 +1,82 @@
+package apikeys
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestGenerate checks that generated keys are prefixed, unique and match their hash
+func TestGenerate(t *testing.T) {
+	first, firstHash, err := Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _, err := Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(first, keyPrefix) {
+		t.Errorf("Expected the key to start with %q, got %q", keyPrefix, first)
+	}
+	if first == second {
+		t.Error("Expected two generated keys to differ")
+	}
+	if firstHash != Hash(first) {
+		t.Error("Expected the returned hash to be the hash of the key")
+	}
+	if len(firstHash) != 64 {
+		t.Errorf("Expected a hex SHA-256 hash, got %q", firstHash)
+	}
+}
+
+// TestEqual checks the comparison of secrets
+func TestEqual(t *testing.T) {
+	testCases := []struct {
+		a, b     string
+		expected bool
+	}{
+		{"av_secret", "av_secret", true},
+		{"av_secret", "av_Secret", false},
+		{"av_secret", "av_secret ", false},
+		{"av_secret", "", false},
+	}
+
+	for _, tc := range testCases {
+		if got := Equal(tc.a, tc.b); got != tc.expected {
+			t.Errorf("Equal(%q, %q) = %v, expected %v", tc.a, tc.b, got, tc.expected)
+		}
+	}
+}
+
+// TestHasScope checks scope checks, with admin implying every scope
+func TestHasScope(t *testing.T) {
+	testCases := []struct {
+		scopes   []string
+		scope    string
+		expected bool
+	}{
+		{[]string{ScopeRead}, ScopeRead, true},
+		{[]string{ScopeRead}, ScopeIngest, false},
+		{[]string{ScopeRead, ScopeIngest}, ScopeIngest, true},
+		{[]string{ScopeAdmin}, ScopeIngest, true},
+		{nil, ScopeRead, false},
+	}
+
+	for _, tc := range testCases {
+		key := APIKey{Scopes: tc.scopes}
+		if got := key.HasScope(tc.scope); got != tc.expected {
+			t.Errorf("HasScope(%q) with scopes %v = %v, expected %v", tc.scope, tc.scopes, got, tc.expected)
+		}
+	}
+}
+
+// TestDisplayPrefix checks that only the beginning of a key is shown
+func TestDisplayPrefix(t *testing.T) {
+	if got := displayPrefix("av_abcdefghijkl"); got != "av_abcdef" {
+		t.Errorf("Expected av_abcdef, got %q", got)
+	}
+	if got := displayPrefix("av_abc"); got != "av_abc" {
+		t.Errorf("Expected short keys to be kept, got %q", got)
+	}
+}
@@ -0,0 +1,204 @@
+package apikeys
+
+import (
+	"astrovista-api/database"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrNotFound is returned when a key does not exist or was revoked
+var ErrNotFound = errors.New("API key not found")
+
+// lookupTTL is how long a resolved key is kept in memory. Revocations made on another
+// replica take at most this long to be seen.
+const lookupTTL = time.Minute
+
+// maxLookupEntries bounds the lookup cache, which also holds unknown keys
+const maxLookupEntries = 10000
+
+// lookupEntry is a cached key lookup
+type lookupEntry struct {
+	key        *APIKey
+	expiration time.Time
+}
+
+var (
+	lookupCache = make(map[string]lookupEntry)
+	lookupMutex sync.RWMutex
+)
+
+// EnsureIndexes creates the indexes used by key lookups
+func EnsureIndexes(ctx context.Context) error {
+	if database.APIKeyCollection == nil {
+		return nil
+	}
+	_, err := database.APIKeyCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "hash", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+// Create issues a new key and returns it along with the plaintext value, which is never stored
+func Create(ctx context.Context, name string, scopes []string, dailyQuota, monthlyQuota int64) (*APIKey, string, error) {
+	for _, scope := range scopes {
+		if !IsValidScope(scope) {
+			return nil, "", fmt.Errorf("invalid scope %q", scope)
+		}
+	}
+
+	plaintext, hash, err := Generate()
+	if err != nil {
+		return nil, "", err
+	}
+
+	key := &APIKey{
+		Name:         name,
+		Prefix:       displayPrefix(plaintext),
+		Hash:         hash,
+		Scopes:       scopes,
+		DailyQuota:   dailyQuota,
+		MonthlyQuota: monthlyQuota,
+		CreatedAt:    time.Now().UTC(),
+	}
+
+	result, err := database.APIKeyCollection.InsertOne(ctx, key)
+	if err != nil {
+		return nil, "", err
+	}
+	key.ID = result.InsertedID.(primitive.ObjectID)
+	return key, plaintext, nil
+}
+
+// FindByKey resolves a plaintext key to an active APIKey
+func FindByKey(ctx context.Context, plaintext string) (*APIKey, error) {
+	hash := Hash(plaintext)
+
+	lookupMutex.RLock()
+	entry, found := lookupCache[hash]
+	lookupMutex.RUnlock()
+	if found && time.Now().Before(entry.expiration) {
+		if entry.key == nil {
+			return nil, ErrNotFound
+		}
+		return entry.key, nil
+	}
+
+	var key APIKey
+	err := database.APIKeyCollection.FindOne(ctx, bson.M{
+		"hash":       hash,
+		"revoked_at": bson.M{"$exists": false},
+	}).Decode(&key)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return nil, err
+	}
+
+	// Unknown keys are cached too, so invalid keys can't be used to hammer the database
+	var cached *APIKey
+	if err == nil {
+		cached = &key
+	}
+	lookupMutex.Lock()
+	if len(lookupCache) >= maxLookupEntries {
+		sweepLookupCacheLocked()
+	}
+	lookupCache[hash] = lookupEntry{key: cached, expiration: time.Now().Add(lookupTTL)}
+	lookupMutex.Unlock()
+
+	if cached == nil {
+		return nil, ErrNotFound
+	}
+	return cached, nil
+}
+
+// List returns every key, including revoked ones
+func List(ctx context.Context) ([]APIKey, error) {
+	cursor, err := database.APIKeyCollection.Find(ctx, bson.M{},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	keys := []APIKey{}
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Rotate replaces the secret of an active key, keeping its scopes, quotas and usage history
+func Rotate(ctx context.Context, id primitive.ObjectID) (*APIKey, string, error) {
+	plaintext, hash, err := Generate()
+	if err != nil {
+		return nil, "", err
+	}
+
+	var previous APIKey
+	now := time.Now().UTC()
+	err = database.APIKeyCollection.FindOneAndUpdate(ctx,
+		bson.M{"_id": id, "revoked_at": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{
+			"hash":       hash,
+			"prefix":     displayPrefix(plaintext),
+			"rotated_at": now,
+		}},
+	).Decode(&previous)
+	if err == mongo.ErrNoDocuments {
+		return nil, "", ErrNotFound
+	} else if err != nil {
+		return nil, "", err
+	}
+	forget(previous.Hash)
+
+	rotated := previous
+	rotated.Hash = hash
+	rotated.Prefix = displayPrefix(plaintext)
+	rotated.RotatedAt = &now
+	return &rotated, plaintext, nil
+}
+
+// Revoke disables a key permanently
+func Revoke(ctx context.Context, id primitive.ObjectID) error {
+	var previous APIKey
+	err := database.APIKeyCollection.FindOneAndUpdate(ctx,
+		bson.M{"_id": id, "revoked_at": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"revoked_at": time.Now().UTC()}},
+	).Decode(&previous)
+	if err == mongo.ErrNoDocuments {
+		return ErrNotFound
+	} else if err != nil {
+		return err
+	}
+	forget(previous.Hash)
+	return nil
+}
+
+// sweepLookupCacheLocked removes expired lookups, or everything if the cache is still full
+// (assumes the lock is already obtained)
+func sweepLookupCacheLocked() {
+	now := time.Now()
+	for hash, entry := range lookupCache {
+		if now.After(entry.expiration) {
+			delete(lookupCache, hash)
+		}
+	}
+	if len(lookupCache) >= maxLookupEntries {
+		lookupCache = make(map[string]lookupEntry)
+	}
+}
+
+// forget removes a key from the local lookup cache
+func forget(hash string) {
+	lookupMutex.Lock()
+	delete(lookupCache, hash)
+	lookupMutex.Unlock()
+}
@@ -0,0 +1,71 @@
+package apikeys
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TestFindByKeyFromLookupCache checks key lookups answered by the lookup cache, and that
+// forgetting a key (as rotation and revocation do) drops it
+func TestFindByKeyFromLookupCache(t *testing.T) {
+	active := &APIKey{ID: primitive.NewObjectID(), Name: "Mobile app", Scopes: []string{ScopeRead}}
+	lookupMutex.Lock()
+	lookupCache[Hash("av_active")] = lookupEntry{key: active, expiration: time.Now().Add(time.Minute)}
+	lookupCache[Hash("av_unknown")] = lookupEntry{key: nil, expiration: time.Now().Add(time.Minute)}
+	lookupMutex.Unlock()
+	defer forget(Hash("av_active"))
+	defer forget(Hash("av_unknown"))
+
+	testCases := []struct {
+		name        string
+		key         string
+		expected    *APIKey
+		expectedErr error
+	}{
+		{"Active key", "av_active", active, nil},
+		{"Unknown or revoked key", "av_unknown", nil, ErrNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, err := FindByKey(context.Background(), tc.key)
+			if err != tc.expectedErr {
+				t.Fatalf("Expected error %v, got %v", tc.expectedErr, err)
+			}
+			if key != tc.expected {
+				t.Errorf("Expected key %+v, got %+v", tc.expected, key)
+			}
+		})
+	}
+
+	forget(Hash("av_active"))
+	lookupMutex.RLock()
+	_, found := lookupCache[Hash("av_active")]
+	lookupMutex.RUnlock()
+	if found {
+		t.Error("Expected a forgotten key to be removed from the lookup cache")
+	}
+}
+
+// TestSweepLookupCache checks that expired lookups are dropped when the cache is full
+func TestSweepLookupCache(t *testing.T) {
+	lookupMutex.Lock()
+	defer lookupMutex.Unlock()
+	previous := lookupCache
+	defer func() { lookupCache = previous }()
+
+	lookupCache = map[string]lookupEntry{
+		"expired": {expiration: time.Now().Add(-time.Second)},
+		"fresh":   {expiration: time.Now().Add(time.Minute)},
+	}
+	sweepLookupCacheLocked()
+	if _, found := lookupCache["expired"]; found {
+		t.Error("Expected the expired lookup to be dropped")
+	}
+	if _, found := lookupCache["fresh"]; !found {
+		t.Error("Expected the fresh lookup to be kept")
+	}
+}
@@ -0,0 +1,80 @@
+package apikeys
+
+import (
+	"astrovista-api/cache"
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Usage holds the request counters of a key (or an anonymous client)
+// swagger:model Usage
+type Usage struct {
+	// Requests made today (UTC)
+	// example: 120
+	Day int64 `json:"day"`
+	// Requests made this month (UTC)
+	// example: 3400
+	Month int64 `json:"month"`
+}
+
+// usageKeys returns the Redis keys of the daily and monthly counters of a subject
+func usageKeys(subject string, now time.Time) (string, string) {
+	now = now.UTC()
+	return "usage:" + subject + ":day:" + now.Format("2006-01-02"),
+		"usage:" + subject + ":month:" + now.Format("2006-01")
+}
+
+// IncrementUsage counts one request for the subject and returns the updated counters.
+// Without Redis, usage is not tracked and zero counters are returned.
+func IncrementUsage(ctx context.Context, subject string) (Usage, error) {
+	if cache.Client == nil {
+		return Usage{}, nil // Usage tracking disabled
+	}
+
+	dayKey, monthKey := usageKeys(subject, time.Now())
+	pipe := cache.Client.TxPipeline()
+	day := pipe.Incr(ctx, dayKey)
+	month := pipe.Incr(ctx, monthKey)
+	// Counters outlive their period a little so reports for the previous period still work
+	pipe.Expire(ctx, dayKey, 48*time.Hour)
+	pipe.Expire(ctx, monthKey, 32*24*time.Hour)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return Usage{}, err
+	}
+
+	return Usage{Day: day.Val(), Month: month.Val()}, nil
+}
+
+// GetUsage returns the current counters of a subject without incrementing them
+func GetUsage(ctx context.Context, subject string) (Usage, error) {
+	if cache.Client == nil {
+		return Usage{}, nil // Usage tracking disabled
+	}
+
+	dayKey, monthKey := usageKeys(subject, time.Now())
+	values, err := cache.Client.MGet(ctx, dayKey, monthKey).Result()
+	if err != nil && err != redis.Nil {
+		return Usage{}, err
+	}
+
+	return Usage{Day: parseCounter(values[0]), Month: parseCounter(values[1])}, nil
+}
+
+// parseCounter converts a value returned by MGET into a number
+func parseCounter(value interface{}) int64 {
+	str, ok := value.(string)
+	if !ok {
+		return 0 // Counter does not exist yet
+	}
+	n, _ := strconv.ParseInt(str, 10, 64)
+	return n
+}
+
+// QuotaExceeded checks the counters against the quotas (0 means unlimited)
+func QuotaExceeded(usage Usage, dailyQuota, monthlyQuota int64) bool {
+	return (dailyQuota > 0 && usage.Day > dailyQuota) ||
+		(monthlyQuota > 0 && usage.Month > monthlyQuota)
+}
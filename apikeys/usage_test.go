@@ -0,0 +1,46 @@
+package apikeys
+
+import (
+	"testing"
+	"time"
+)
+
+// TestQuotaExceeded checks the quota boundaries: usage counts the current request, so a
+// quota of N allows exactly N requests
+func TestQuotaExceeded(t *testing.T) {
+	testCases := []struct {
+		name         string
+		usage        Usage
+		dailyQuota   int64
+		monthlyQuota int64
+		expected     bool
+	}{
+		{"Below both quotas", Usage{Day: 9, Month: 99}, 10, 100, false},
+		{"Last request of the day", Usage{Day: 10, Month: 50}, 10, 100, false},
+		{"One request over the daily quota", Usage{Day: 11, Month: 51}, 10, 100, true},
+		{"Last request of the month", Usage{Day: 5, Month: 100}, 10, 100, false},
+		{"One request over the monthly quota", Usage{Day: 1, Month: 101}, 10, 100, true},
+		{"Unlimited daily quota", Usage{Day: 1000, Month: 1000}, 0, 2000, false},
+		{"Unlimited quotas", Usage{Day: 1 << 40, Month: 1 << 40}, 0, 0, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := QuotaExceeded(tc.usage, tc.dailyQuota, tc.monthlyQuota); got != tc.expected {
+				t.Errorf("QuotaExceeded(%+v, %d, %d) = %v, expected %v", tc.usage, tc.dailyQuota, tc.monthlyQuota, got, tc.expected)
+			}
+		})
+	}
+}
+
+// TestUsageKeys checks that counters are per subject and per UTC period
+func TestUsageKeys(t *testing.T) {
+	now := time.Date(2025, 1, 31, 23, 30, 0, 0, time.FixedZone("UTC-3", -3*60*60))
+	day, month := usageKeys("key:abc", now)
+	if day != "usage:key:abc:day:2025-02-01" {
+		t.Errorf("Unexpected daily key %q", day)
+	}
+	if month != "usage:key:abc:month:2025-02" {
+		t.Errorf("Unexpected monthly key %q", month)
+	}
+}
@@ -0,0 +1,248 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrKeyNotFound is returned when no key matches the token's key ID
+var ErrKeyNotFound = errors.New("signing key not found")
+
+// jwk is a JSON Web Key as defined in RFC 7517 (only the fields we need)
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// jwkSet is a JSON Web Key Set document
+type jwkSet struct {
+	Keys []jwk `json:"keys"`
+}
+
+// KeySet holds the public keys used to verify tokens, loaded from a JWKS URL or a local file
+type KeySet struct {
+	mutex      sync.RWMutex
+	keys       map[string]crypto.PublicKey
+	url        string
+	httpClient *http.Client
+	lastFetch  time.Time
+	// minRefreshInterval limits how often unknown key IDs trigger a refetch
+	minRefreshInterval time.Duration
+}
+
+// NewRemoteKeySet creates a key set that fetches keys from a JWKS URL (e.g. an OIDC provider)
+func NewRemoteKeySet(url string) (*KeySet, error) {
+	ks := &KeySet{
+		keys: make(map[string]crypto.PublicKey),
+		url:  url,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		minRefreshInterval: time.Minute,
+	}
+	if err := ks.refresh(); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+// NewFileKeySet loads keys from a local file containing either a JWKS document or
+// a PEM-encoded public key (useful for tests and development)
+func NewFileKeySet(path string) (*KeySet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading key file: %v", err)
+	}
+
+	ks := &KeySet{keys: make(map[string]crypto.PublicKey)}
+	if block, _ := pem.Decode(data); block != nil {
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing PEM public key: %v", err)
+		}
+		ks.keys[""] = key // Matches tokens without a key ID
+		return ks, nil
+	}
+
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return nil, err
+	}
+	ks.keys = keys
+	return ks, nil
+}
+
+// Key returns the public key for a key ID, refetching the JWKS if the ID is unknown
+// (providers rotate keys by publishing new IDs)
+func (ks *KeySet) Key(kid string) (crypto.PublicKey, error) {
+	if key, ok := ks.lookup(kid); ok {
+		return key, nil
+	}
+
+	if ks.url != "" {
+		ks.mutex.RLock()
+		canRefresh := time.Since(ks.lastFetch) >= ks.minRefreshInterval
+		ks.mutex.RUnlock()
+		if canRefresh {
+			if err := ks.refresh(); err != nil {
+				log.Printf("Error refreshing JWKS: %v", err)
+			}
+			if key, ok := ks.lookup(kid); ok {
+				return key, nil
+			}
+		}
+	}
+	return nil, ErrKeyNotFound
+}
+
+// lookup finds a key by ID. A set with a single key also matches tokens without an ID.
+func (ks *KeySet) lookup(kid string) (crypto.PublicKey, bool) {
+	ks.mutex.RLock()
+	defer ks.mutex.RUnlock()
+
+	if key, ok := ks.keys[kid]; ok {
+		return key, true
+	}
+	if kid == "" && len(ks.keys) == 1 {
+		for _, key := range ks.keys {
+			return key, true
+		}
+	}
+	return nil, false
+}
+
+// refresh downloads the JWKS document and replaces the current keys
+func (ks *KeySet) refresh() error {
+	ks.mutex.Lock()
+	ks.lastFetch = time.Now()
+	ks.mutex.Unlock()
+
+	resp, err := ks.httpClient.Get(ks.url)
+	if err != nil {
+		return fmt.Errorf("error fetching JWKS: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("JWKS endpoint returned non-OK status: %d", resp.StatusCode)
+	}
+
+	var document json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&document); err != nil {
+		return fmt.Errorf("error decoding JWKS: %v", err)
+	}
+	keys, err := parseJWKS(document)
+	if err != nil {
+		return err
+	}
+
+	ks.mutex.Lock()
+	ks.keys = keys
+	ks.mutex.Unlock()
+	return nil
+}
+
+// parseJWKS parses the signing keys of a JWKS document
+func parseJWKS(data []byte) (map[string]crypto.PublicKey, error) {
+	var set jwkSet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("error decoding JWKS: %v", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey)
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue // Encryption keys can't verify signatures
+		}
+		key, err := parseJWK(k)
+		if err != nil {
+			log.Printf("Skipping JWK %q: %v", k.Kid, err)
+			continue
+		}
+		keys[k.Kid] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("JWKS contains no usable signing keys")
+	}
+	return keys, nil
+}
+
+// parseJWK converts a JWK into a Go public key
+func parseJWK(k jwk) (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+}
+
+// decodeBigInt decodes a base64url-encoded big-endian integer
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64url value: %v", err)
+	}
+	return new(big.Int).SetBytes(data), nil
+}
@@ -0,0 +1,161 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Config configures JWT validation
+type Config struct {
+	JWKSURL    string            // JWKS endpoint of the identity provider
+	JWKSFile   string            // Local JWKS or PEM public key file (takes precedence over the URL)
+	Issuer     string            // Expected "iss" claim (optional)
+	Audience   string            // Expected "aud" claim (optional)
+	RolesClaim string            // Claim holding the user's roles, dots for nested claims
+	RoleMap    map[string]string // Maps claim values to API roles; empty means values are used as-is
+}
+
+// ConfigFromEnv reads the JWT configuration from environment variables
+func ConfigFromEnv() Config {
+	config := Config{
+		JWKSURL:    os.Getenv("AUTH_JWKS_URL"),
+		JWKSFile:   os.Getenv("AUTH_JWKS_FILE"),
+		Issuer:     os.Getenv("AUTH_ISSUER"),
+		Audience:   os.Getenv("AUTH_AUDIENCE"),
+		RolesClaim: os.Getenv("AUTH_ROLES_CLAIM"),
+		RoleMap:    make(map[string]string),
+	}
+	if config.RolesClaim == "" {
+		config.RolesClaim = "roles"
+	}
+
+	// AUTH_ROLE_MAP has the form "claim-value=role,other-value=role"
+	for _, pair := range strings.Split(os.Getenv("AUTH_ROLE_MAP"), ",") {
+		if from, to, found := strings.Cut(strings.TrimSpace(pair), "="); found {
+			config.RoleMap[strings.TrimSpace(from)] = strings.TrimSpace(to)
+		}
+	}
+	return config
+}
+
+// Enabled reports whether a key source is configured
+func (c Config) Enabled() bool {
+	return c.JWKSURL != "" || c.JWKSFile != ""
+}
+
+// Identity is the authenticated user of a verified token
+type Identity struct {
+	Subject string
+	Roles   []string
+}
+
+// Verifier validates JWTs and maps their claims to roles
+type Verifier struct {
+	config Config
+	keys   *KeySet
+	parser *jwt.Parser
+}
+
+// NewVerifier creates a verifier, loading the configured keys
+func NewVerifier(config Config) (*Verifier, error) {
+	var keys *KeySet
+	var err error
+	if config.JWKSFile != "" {
+		keys, err = NewFileKeySet(config.JWKSFile)
+	} else if config.JWKSURL != "" {
+		keys, err = NewRemoteKeySet(config.JWKSURL)
+	} else {
+		return nil, errors.New("no JWKS URL or key file configured")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	options := []jwt.ParserOption{
+		// Only asymmetric algorithms: a public key must never be usable as an HMAC secret
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(30 * time.Second),
+	}
+	if config.Issuer != "" {
+		options = append(options, jwt.WithIssuer(config.Issuer))
+	}
+	if config.Audience != "" {
+		options = append(options, jwt.WithAudience(config.Audience))
+	}
+
+	return &Verifier{
+		config: config,
+		keys:   keys,
+		parser: jwt.NewParser(options...),
+	}, nil
+}
+
+// Verify checks the token signature and standard claims and returns the identity
+func (v *Verifier) Verify(tokenString string) (*Identity, error) {
+	claims := jwt.MapClaims{}
+	_, err := v.parser.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return v.keys.Key(kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid token: %v", err)
+	}
+
+	subject, _ := claims.GetSubject()
+	if subject == "" {
+		return nil, errors.New("invalid token: missing subject")
+	}
+
+	return &Identity{
+		Subject: subject,
+		Roles:   v.mapRoles(claimValues(claims, v.config.RolesClaim)),
+	}, nil
+}
+
+// mapRoles translates claim values into API roles
+func (v *Verifier) mapRoles(values []string) []string {
+	if len(v.config.RoleMap) == 0 {
+		return values
+	}
+
+	var roles []string
+	for _, value := range values {
+		if role, ok := v.config.RoleMap[value]; ok {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+// claimValues reads a claim as a list of strings. Nested claims use dots
+// (e.g. "realm_access.roles") and space-separated strings (like "scope") are split.
+func claimValues(claims jwt.MapClaims, path string) []string {
+	var current interface{} = map[string]interface{}(claims)
+	for _, part := range strings.Split(path, ".") {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = object[part]
+	}
+
+	switch value := current.(type) {
+	case string:
+		return strings.Fields(value)
+	case []interface{}:
+		var values []string
+		for _, item := range value {
+			if str, ok := item.(string); ok {
+				values = append(values, str)
+			}
+		}
+		return values
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// writeTestJWKS generates an RSA key and writes its public part as a JWKS file
+func writeTestJWKS(t *testing.T) (*rsa.PrivateKey, string) {
+	t.Helper()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	document := map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "test-key",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(privateKey.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(privateKey.E)).Bytes()),
+		}},
+	}
+	data, err := json.Marshal(document)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return privateKey, path
+}
+
+// signTestToken signs the claims with the test key
+func signTestToken(t *testing.T, key *rsa.PrivateKey, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "test-key"
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+// TestVerifier verifies token validation and role mapping with a local JWKS file
+func TestVerifier(t *testing.T) {
+	privateKey, jwksPath := writeTestJWKS(t)
+
+	verifier, err := NewVerifier(Config{
+		JWKSFile:   jwksPath,
+		Issuer:     "https://auth.example.com/",
+		Audience:   "astrovista-api",
+		RolesClaim: "realm_access.roles",
+		RoleMap:    map[string]string{"apod-admins": "admin", "apod-ingest": "ingest"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"sub":          "operator@example.com",
+			"iss":          "https://auth.example.com/",
+			"aud":          "astrovista-api",
+			"exp":          time.Now().Add(time.Hour).Unix(),
+			"realm_access": map[string]interface{}{"roles": []string{"apod-admins", "unrelated"}},
+		}
+	}
+
+	t.Run("Valid token", func(t *testing.T) {
+		identity, err := verifier.Verify(signTestToken(t, privateKey, validClaims()))
+		if err != nil {
+			t.Fatalf("Valid token was rejected: %v", err)
+		}
+		if identity.Subject != "operator@example.com" {
+			t.Errorf("Unexpected subject %q", identity.Subject)
+		}
+		if len(identity.Roles) != 1 || identity.Roles[0] != "admin" {
+			t.Errorf("Expected roles [admin], got %v", identity.Roles)
+		}
+	})
+
+	rejected := map[string]func(jwt.MapClaims){
+		"Expired token":  func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() },
+		"Missing expiry": func(c jwt.MapClaims) { delete(c, "exp") },
+		"Wrong issuer":   func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com/" },
+		"Wrong audience": func(c jwt.MapClaims) { c["aud"] = "another-api" },
+	}
+	for name, modify := range rejected {
+		t.Run(name, func(t *testing.T) {
+			claims := validClaims()
+			modify(claims)
+			if _, err := verifier.Verify(signTestToken(t, privateKey, claims)); err == nil {
+				t.Errorf("Token should have been rejected")
+			}
+		})
+	}
+
+	t.Run("Token signed by another key", func(t *testing.T) {
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := verifier.Verify(signTestToken(t, otherKey, validClaims())); err == nil {
+			t.Errorf("Token signed by an unknown key should have been rejected")
+		}
+	})
+
+	t.Run("HMAC token using the public key as secret", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, validClaims())
+		token.Header["kid"] = "test-key"
+		signed, err := token.SignedString(privateKey.N.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := verifier.Verify(signed); err == nil {
+			t.Errorf("HMAC tokens must be rejected")
+		}
+	})
+}
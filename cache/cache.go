@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cache backends selected by CACHE_BACKEND
+const (
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+	BackendTiered = "tiered"
+)
+
+// Cache stores JSON-encoded values under string keys
+type Cache interface {
+	// Get decodes the value of key into dest and reports whether it was found
+	Get(ctx context.Context, key string, dest interface{}) (bool, error)
+	// Set stores value under key for the given duration
+	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+	// Delete removes key
+	Delete(ctx context.Context, key string) error
+	// Clear removes every key
+	Clear(ctx context.Context) error
+}
+
+// NewFromEnv creates the cache selected by CACHE_BACKEND: "memory" for an in-process LRU,
+// "redis" for Redis alone, or "tiered" for an in-process LRU in front of Redis. It defaults
+// to "tiered" when Redis is connected and to "memory" otherwise, so caching keeps working on
+// a single instance without Redis. Entries can be invalidated by tag, through an index in
+// Redis when it is used. Call Connect first.
+func NewFromEnv() Cache {
+	backend := strings.ToLower(strings.TrimSpace(os.Getenv("CACHE_BACKEND")))
+	switch backend {
+	case "":
+		backend = BackendTiered
+	case BackendMemory, BackendRedis, BackendTiered:
+	default:
+		log.Printf("Invalid value for CACHE_BACKEND ignored: %s (using %s as default)", backend, BackendTiered)
+		backend = BackendTiered
+	}
+	if backend != BackendMemory && Client == nil {
+		if os.Getenv("CACHE_BACKEND") != "" {
+			log.Printf("Warning: CACHE_BACKEND=%s needs Redis, using the in-memory cache instead", backend)
+		}
+		backend = BackendMemory
+	}
+
+	maxEntries := 10000
+	if value := os.Getenv("CACHE_MAX_ENTRIES"); value != "" {
+		if n, err := strconv.Atoi(value); err == nil && n > 0 {
+			maxEntries = n
+		} else {
+			log.Printf("Invalid value for CACHE_MAX_ENTRIES ignored: %s (using %d as default)", value, maxEntries)
+		}
+	}
+
+	log.Printf("Response cache backend: %s", backend)
+	switch backend {
+	case BackendRedis:
+		return NewTaggedCache(NewRedisCache(Client), NewRedisTagIndex(Client))
+	case BackendTiered:
+		l1TTL := time.Minute
+		if value := os.Getenv("CACHE_L1_TTL"); value != "" {
+			if d, err := time.ParseDuration(value); err == nil && d > 0 {
+				l1TTL = d
+			} else {
+				log.Printf("Invalid value for CACHE_L1_TTL ignored: %s (using %s as default)", value, l1TTL)
+			}
+		}
+		tiered := NewTieredCache(NewMemoryCache(maxEntries), NewRedisCache(Client), l1TTL)
+		return NewTaggedCache(tiered, NewRedisTagIndex(Client))
+	default:
+		return NewTaggedCache(NewMemoryCache(maxEntries), NewMemoryTagIndex())
+	}
+}
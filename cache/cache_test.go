@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type testItem struct {
+	Title string `json:"title"`
+}
+
+// TestMemoryCacheLRU checks that the least recently used item is evicted when full
+func TestMemoryCacheLRU(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache(2)
+	c.Set(ctx, "a", testItem{"A"}, 0)
+	c.Set(ctx, "b", testItem{"B"}, 0)
+
+	// Reading "a" makes "b" the least recently used item
+	var item testItem
+	if found, err := c.Get(ctx, "a", &item); err != nil || !found || item.Title != "A" {
+		t.Fatalf("Expected a to be cached, got %+v (%v, %v)", item, found, err)
+	}
+	c.Set(ctx, "c", testItem{"C"}, 0)
+
+	if found, _ := c.Get(ctx, "b", &item); found {
+		t.Error("Expected b to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if found, _ := c.Get(ctx, key, &item); !found {
+			t.Errorf("Expected %s to be cached", key)
+		}
+	}
+	if c.Len() != 2 {
+		t.Errorf("Expected 2 items, got %d", c.Len())
+	}
+}
+
+// TestMemoryCacheExpiration checks that expired items are not returned
+func TestMemoryCacheExpiration(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache(10)
+	c.Set(ctx, "apod:latest", testItem{"M31"}, time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	var item testItem
+	if found, _ := c.Get(ctx, "apod:latest", &item); found {
+		t.Error("Expected the item to be expired")
+	}
+	if c.Len() != 0 {
+		t.Errorf("Expected the expired item to be removed, got %d items", c.Len())
+	}
+}
+
+// TestTieredCache checks that L2 hits are copied into L1 and that deletes reach both tiers
+func TestTieredCache(t *testing.T) {
+	ctx := context.Background()
+	l1, l2 := NewMemoryCache(10), NewMemoryCache(10)
+	c := NewTieredCache(l1, l2, time.Minute)
+
+	// Written by another instance, only in L2
+	l2.Set(ctx, "apod:2025-06-10", testItem{"NGC 7000"}, time.Hour)
+
+	var item testItem
+	if found, err := c.Get(ctx, "apod:2025-06-10", &item); err != nil || !found || item.Title != "NGC 7000" {
+		t.Fatalf("Expected an L2 hit, got %+v (%v, %v)", item, found, err)
+	}
+	if found, _ := l1.Get(ctx, "apod:2025-06-10", &item); !found {
+		t.Error("Expected the L2 hit to be copied into L1")
+	}
+
+	c.Set(ctx, "apod:latest", testItem{"M31"}, time.Hour)
+	if l1.Len() != 2 || l2.Len() != 2 {
+		t.Errorf("Expected both tiers to hold 2 items, got %d and %d", l1.Len(), l2.Len())
+	}
+
+	c.Delete(ctx, "apod:latest")
+	for name, tier := range map[string]*MemoryCache{"L1": l1, "L2": l2} {
+		if found, _ := tier.Get(ctx, "apod:latest", &item); found {
+			t.Errorf("Expected the item to be deleted from %s", name)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Status tells where a value returned by a Loader came from, as reported in X-Cache
+type Status string
+
+const (
+	// StatusHit is a fresh value from the cache
+	StatusHit Status = "HIT"
+	// StatusStale is a value past its TTL, served while it is refreshed in the background
+	StatusStale Status = "STALE"
+	// StatusMiss is a value just loaded
+	StatusMiss Status = "MISS"
+)
+
+// Policy sets how long a loaded value is served
+type Policy struct {
+	// TTL is how long the value is fresh
+	TTL time.Duration
+	// Stale is how long after TTL the value is still served while it is refreshed
+	Stale time.Duration
+	// Tags the value is invalidated by, when the cache supports tags
+	Tags []string
+}
+
+// WithTags returns a copy of the policy with the tags added
+func (p Policy) WithTags(tags ...string) Policy {
+	p.Tags = append(append([]string(nil), p.Tags...), tags...)
+	return p
+}
+
+// LoadFunc loads the value of a key when it is missing or stale
+type LoadFunc func(ctx context.Context) (interface{}, error)
+
+// loaderEntry is a value stored by a Loader, with the time it stops being fresh
+type loaderEntry struct {
+	Value      json.RawMessage `json:"value"`
+	FreshUntil time.Time       `json:"fresh_until"`
+}
+
+const (
+	// refreshTimeout bounds a background refresh, which outlives the request that started it
+	refreshTimeout = 15 * time.Second
+	// loadTimeout bounds a load shared by concurrent misses, which outlives the callers
+	// that give up on it
+	loadTimeout = 15 * time.Second
+	// lockWait is how long a miss waits for the replica holding the lock to fill the cache
+	lockWait = 2 * time.Second
+	// lockPollInterval is how often the cache is checked while waiting for the lock holder
+	lockPollInterval = 50 * time.Millisecond
+)
+
+// Loader reads values through a Cache and protects the loaders behind it from stampedes:
+// concurrent misses of a key share a single load, and values past their TTL are served
+// stale while a single goroutine refreshes them. With a Locker, replicas also agree on
+// which one loads a key.
+type Loader struct {
+	cache  Cache
+	locker Locker
+	group  singleflight.Group
+}
+
+// NewLoader creates a loader over a cache. locker may be nil to coalesce loads within
+// this instance only.
+func NewLoader(c Cache, locker Locker) *Loader {
+	return &Loader{cache: c, locker: locker}
+}
+
+// Cache returns the cache the loader reads through
+func (l *Loader) Cache() Cache {
+	return l.cache
+}
+
+// Fetch decodes the value of key into dest, loading it with load when it is not cached.
+// Errors of load are returned as is and nothing is cached; cache errors are only logged.
+// The load is shared by every caller missing the key, so it doesn't stop when the caller
+// that started it goes away: each caller only stops waiting for it when its own ctx ends.
+func (l *Loader) Fetch(ctx context.Context, key string, policy Policy, dest interface{}, load LoadFunc) (Status, error) {
+	var entry loaderEntry
+	found, err := l.cache.Get(ctx, key, &entry)
+	if err != nil {
+		log.Printf("Error accessing cache for %s: %v", key, err)
+	}
+	if found {
+		if err := json.Unmarshal(entry.Value, dest); err == nil {
+			if time.Now().Before(entry.FreshUntil) {
+				return StatusHit, nil
+			}
+			go l.refresh(key, policy, load)
+			return StatusStale, nil
+		}
+	}
+
+	results := l.group.DoChan(key, func() (interface{}, error) {
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), loadTimeout)
+		defer cancel()
+		return l.loadLocked(loadCtx, key, policy, load)
+	})
+	var result singleflight.Result
+	select {
+	case result = <-results:
+	case <-ctx.Done():
+		return StatusMiss, ctx.Err()
+	}
+	if result.Err != nil {
+		return StatusMiss, result.Err
+	}
+	value, ok := result.Val.(json.RawMessage)
+	if !ok {
+		return StatusMiss, errors.New("cache: no value loaded for " + key)
+	}
+	return StatusMiss, json.Unmarshal(value, dest)
+}
+
+// refresh reloads a stale value in the background, unless this instance or another
+// replica is already loading it
+func (l *Loader) refresh(key string, policy Policy, load LoadFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), refreshTimeout)
+	defer cancel()
+
+	// Refreshes have their own key, so a miss never joins a refresh that loads nothing
+	l.group.Do("refresh:"+key, func() (interface{}, error) {
+		if l.locker != nil {
+			unlock, acquired, err := l.locker.TryLock(ctx, key, refreshTimeout)
+			if err != nil {
+				log.Printf("Error acquiring cache lock for %s: %v", key, err)
+			} else if !acquired {
+				return nil, nil // Another replica is refreshing it
+			} else {
+				defer unlock()
+			}
+		}
+		value, err := l.store(ctx, key, policy, load)
+		if err != nil {
+			log.Printf("Error refreshing cache for %s: %v", key, err)
+		}
+		return value, err
+	})
+}
+
+// loadLocked loads a missing value. With a Locker, a replica that doesn't get the lock
+// waits for the holder to fill the cache, and loads the value itself if it doesn't.
+func (l *Loader) loadLocked(ctx context.Context, key string, policy Policy, load LoadFunc) (json.RawMessage, error) {
+	if l.locker != nil {
+		unlock, acquired, err := l.locker.TryLock(ctx, key, refreshTimeout)
+		switch {
+		case err != nil:
+			log.Printf("Error acquiring cache lock for %s: %v", key, err)
+		case acquired:
+			defer unlock()
+		default:
+			if value, ok := l.waitForValue(ctx, key); ok {
+				return value, nil
+			}
+		}
+	}
+	return l.store(ctx, key, policy, load)
+}
+
+// waitForValue polls the cache while another replica loads a key
+func (l *Loader) waitForValue(ctx context.Context, key string) (json.RawMessage, bool) {
+	deadline := time.NewTimer(lockWait)
+	defer deadline.Stop()
+	ticker := time.NewTicker(lockPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, false
+		case <-deadline.C:
+			return nil, false
+		case <-ticker.C:
+			var entry loaderEntry
+			if found, err := l.cache.Get(ctx, key, &entry); err == nil && found && time.Now().Before(entry.FreshUntil) {
+				return entry.Value, true
+			}
+		}
+	}
+}
+
+// store loads a value and caches it for its TTL plus its stale period. A value whose tags
+// were invalidated while it was loading may predate the change, so it is returned but
+// not cached.
+func (l *Loader) store(ctx context.Context, key string, policy Policy, load LoadFunc) (json.RawMessage, error) {
+	tagger, tagged := l.cache.(Tagger)
+	tagged = tagged && len(policy.Tags) > 0
+	var generation int64
+	if tagged {
+		var err error
+		if generation, err = tagger.TagGeneration(ctx, policy.Tags...); err != nil {
+			log.Printf("Error reading cache tags of %s: %v", key, err)
+			generation = -1 // An invalidation could be missed, so the value is not cached
+		}
+	}
+
+	value, err := load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	entry := loaderEntry{Value: data, FreshUntil: time.Now().Add(policy.TTL)}
+	if tagged {
+		if current, err := tagger.TagGeneration(ctx, policy.Tags...); err != nil || current != generation {
+			return data, nil
+		}
+		err = tagger.SetWithTags(ctx, key, entry, policy.TTL+policy.Stale, policy.Tags...)
+	} else {
+		err = l.cache.Set(ctx, key, entry, policy.TTL+policy.Stale)
+	}
+	if err != nil {
+		log.Printf("Error storing in cache: %v", err)
+	}
+	return data, nil
+}
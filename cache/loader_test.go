@@ -0,0 +1,276 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestLoaderCoalescesMisses checks that concurrent misses of a key share a single load
+func TestLoaderCoalescesMisses(t *testing.T) {
+	loader := NewLoader(NewMemoryCache(10), nil)
+	policy := Policy{TTL: time.Hour}
+	var loads atomic.Int32
+	release := make(chan struct{})
+	load := func(ctx context.Context) (interface{}, error) {
+		loads.Add(1)
+		<-release
+		return testItem{"M31"}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var item testItem
+			status, err := loader.Fetch(context.Background(), "apod:latest", policy, &item, load)
+			if err != nil || status != StatusMiss || item.Title != "M31" {
+				t.Errorf("Expected a loaded M31, got %+v (%s, %v)", item, status, err)
+			}
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if loads.Load() != 1 {
+		t.Errorf("Expected a single load, got %d", loads.Load())
+	}
+	var item testItem
+	if status, _ := loader.Fetch(context.Background(), "apod:latest", policy, &item, load); status != StatusHit {
+		t.Errorf("Expected a hit after the load, got %s", status)
+	}
+}
+
+// TestLoaderServesStale checks that a value past its TTL is served while it is refreshed
+func TestLoaderServesStale(t *testing.T) {
+	loader := NewLoader(NewMemoryCache(10), nil)
+	policy := Policy{TTL: 50 * time.Millisecond, Stale: time.Hour}
+	var version atomic.Int32
+	refreshed := make(chan struct{}, 1)
+	load := func(ctx context.Context) (interface{}, error) {
+		if version.Add(1) > 1 {
+			defer func() { refreshed <- struct{}{} }()
+		}
+		return version.Load(), nil
+	}
+
+	var value int32
+	if _, err := loader.Fetch(context.Background(), "search:nebula", policy, &value, load); err != nil || value != 1 {
+		t.Fatalf("Expected version 1, got %d (%v)", value, err)
+	}
+	time.Sleep(60 * time.Millisecond)
+
+	status, err := loader.Fetch(context.Background(), "search:nebula", policy, &value, load)
+	if err != nil || status != StatusStale || value != 1 {
+		t.Fatalf("Expected the stale version 1, got %d (%s, %v)", value, status, err)
+	}
+	select {
+	case <-refreshed:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the stale value to be refreshed in the background")
+	}
+	// The refresh stores the value right after loading it
+	time.Sleep(5 * time.Millisecond)
+
+	status, err = loader.Fetch(context.Background(), "search:nebula", policy, &value, load)
+	if err != nil || status != StatusHit || value != 2 {
+		t.Errorf("Expected the refreshed version 2, got %d (%s, %v)", value, status, err)
+	}
+}
+
+// TestLoaderDoesNotCacheErrors checks that a failed load is retried by the next request
+func TestLoaderDoesNotCacheErrors(t *testing.T) {
+	loader := NewLoader(NewMemoryCache(10), nil)
+	policy := Policy{TTL: time.Hour}
+	failure := errors.New("database unavailable")
+
+	var item testItem
+	_, err := loader.Fetch(context.Background(), "apod:latest", policy, &item, func(ctx context.Context) (interface{}, error) {
+		return nil, failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("Expected the load error, got %v", err)
+	}
+
+	status, err := loader.Fetch(context.Background(), "apod:latest", policy, &item, func(ctx context.Context) (interface{}, error) {
+		return testItem{"M31"}, nil
+	})
+	if err != nil || status != StatusMiss || item.Title != "M31" {
+		t.Errorf("Expected the value to be loaded again, got %+v (%s, %v)", item, status, err)
+	}
+}
+
+// busyLocker is a Locker whose locks are always held by another replica
+type busyLocker struct{}
+
+func (busyLocker) TryLock(ctx context.Context, key string, ttl time.Duration) (func(), bool, error) {
+	return nil, false, nil
+}
+
+// TestLoaderWaitsForLockHolder checks that a replica without the lock uses the value
+// loaded by the replica holding it
+func TestLoaderWaitsForLockHolder(t *testing.T) {
+	shared := NewMemoryCache(10)
+	loader := NewLoader(shared, busyLocker{})
+	policy := Policy{TTL: time.Hour}
+
+	// The replica holding the lock stores the value shortly after
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		NewLoader(shared, nil).Fetch(context.Background(), "apod:latest", policy, new(testItem), func(ctx context.Context) (interface{}, error) {
+			return testItem{"NGC 7000"}, nil
+		})
+	}()
+
+	var item testItem
+	_, err := loader.Fetch(context.Background(), "apod:latest", policy, &item, func(ctx context.Context) (interface{}, error) {
+		t.Error("Expected the value of the lock holder to be used")
+		return testItem{"M31"}, nil
+	})
+	if err != nil || item.Title != "NGC 7000" {
+		t.Errorf("Expected NGC 7000, got %+v (%v)", item, err)
+	}
+}
+
+// blockingLocker is a Locker whose locks are held by another replica, and which only
+// answers once released. Each call is reported on calls.
+type blockingLocker struct {
+	calls   chan struct{}
+	release chan struct{}
+}
+
+func (l blockingLocker) TryLock(ctx context.Context, key string, ttl time.Duration) (func(), bool, error) {
+	l.calls <- struct{}{}
+	<-l.release
+	return nil, false, nil
+}
+
+// TestLoaderMissDuringRefresh checks that a miss does not join a background refresh that
+// loads nothing because another replica holds the lock
+func TestLoaderMissDuringRefresh(t *testing.T) {
+	shared := NewMemoryCache(10)
+	policy := Policy{TTL: time.Millisecond, Stale: time.Hour}
+	NewLoader(shared, nil).Fetch(context.Background(), "apod:latest", policy, new(testItem), func(ctx context.Context) (interface{}, error) {
+		return testItem{"M31"}, nil
+	})
+	time.Sleep(5 * time.Millisecond)
+
+	locker := blockingLocker{calls: make(chan struct{}, 2), release: make(chan struct{})}
+	loader := NewLoader(shared, locker)
+	load := func(ctx context.Context) (interface{}, error) {
+		return testItem{"NGC 7000"}, nil
+	}
+	if status, _ := loader.Fetch(context.Background(), "apod:latest", policy, new(testItem), load); status != StatusStale {
+		t.Fatalf("Expected a stale value, got %s", status)
+	}
+	<-locker.calls // The refresh is waiting for the lock
+
+	// The entry is evicted while the refresh waits for the lock
+	shared.Delete(context.Background(), "apod:latest")
+	done := make(chan struct{})
+	var item testItem
+	var err error
+	go func() {
+		defer close(done)
+		// The lock is still held by another replica, so the miss waits for it before loading
+		ctx, cancel := context.WithTimeout(context.Background(), lockWait+time.Second)
+		defer cancel()
+		_, err = loader.Fetch(ctx, "apod:latest", policy, &item, load)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	close(locker.release)
+	<-done
+
+	if err != nil || item.Title != "NGC 7000" {
+		t.Errorf("Expected the miss to load NGC 7000, got %+v (%v)", item, err)
+	}
+}
+
+// TestLoaderSkipsStoreAfterInvalidation checks that a value loaded while its tags were
+// invalidated is returned but not cached
+func TestLoaderSkipsStoreAfterInvalidation(t *testing.T) {
+	c := NewTaggedCache(NewMemoryCache(10), NewMemoryTagIndex())
+	loader := NewLoader(c, nil)
+	policy := Policy{TTL: time.Hour, Tags: []string{"latest"}}
+
+	var item testItem
+	status, err := loader.Fetch(context.Background(), "apod:latest", policy, &item, func(ctx context.Context) (interface{}, error) {
+		// A write lands after the value was read from the database
+		c.InvalidateTags(ctx, "latest")
+		return testItem{"M31"}, nil
+	})
+	if err != nil || status != StatusMiss || item.Title != "M31" {
+		t.Fatalf("Expected a loaded M31, got %+v (%s, %v)", item, status, err)
+	}
+
+	status, err = loader.Fetch(context.Background(), "apod:latest", policy, &item, func(ctx context.Context) (interface{}, error) {
+		return testItem{"NGC 7000"}, nil
+	})
+	if err != nil || status != StatusMiss || item.Title != "NGC 7000" {
+		t.Errorf("Expected the value loaded across the invalidation not to be cached, got %+v (%s, %v)", item, status, err)
+	}
+	if status, _ := loader.Fetch(context.Background(), "apod:latest", policy, &item, nil); status != StatusHit {
+		t.Errorf("Expected a hit once no invalidation happened during the load, got %s", status)
+	}
+}
+
+// TestLoaderSharedLoadOutlivesCaller checks that the caller starting a shared load can
+// give up on it without failing the other callers, and that the value is still cached
+func TestLoaderSharedLoadOutlivesCaller(t *testing.T) {
+	loader := NewLoader(NewMemoryCache(10), nil)
+	policy := Policy{TTL: time.Hour}
+	started := make(chan struct{})
+	release := make(chan struct{})
+	load := func(ctx context.Context) (interface{}, error) {
+		close(started)
+		select {
+		case <-release:
+			return testItem{"M31"}, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	firstDone := make(chan error, 1)
+	go func() {
+		var item testItem
+		_, err := loader.Fetch(ctx, "apod:latest", policy, &item, load)
+		firstDone <- err
+	}()
+	<-started
+
+	secondDone := make(chan testItem, 1)
+	go func() {
+		var item testItem
+		if _, err := loader.Fetch(context.Background(), "apod:latest", policy, &item, load); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		secondDone <- item
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	cancel()
+	select {
+	case err := <-firstDone:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected the canceled caller to get context.Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the canceled caller to return without waiting for the load")
+	}
+
+	close(release)
+	if item := <-secondDone; item.Title != "M31" {
+		t.Errorf("Expected the other caller to get M31, got %+v", item)
+	}
+	var item testItem
+	if status, _ := loader.Fetch(context.Background(), "apod:latest", policy, &item, load); status != StatusHit {
+		t.Errorf("Expected the loaded value to be cached, got %s", status)
+	}
+}
@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Locker grants short-lived exclusive locks on keys, so that a single replica loads a
+// missing or stale value
+type Locker interface {
+	// TryLock acquires the lock of key for at most ttl without waiting, reporting whether
+	// it was acquired. unlock releases it.
+	TryLock(ctx context.Context, key string, ttl time.Duration) (unlock func(), acquired bool, err error)
+}
+
+// unlockScript releases a lock only if it is still held by the same owner, so a lock that
+// expired and was taken by another replica is not released by mistake
+var unlockScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// RedisLocker is a Locker shared by all API replicas through Redis
+type RedisLocker struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisLocker creates a Redis-backed locker
+func NewRedisLocker(client *redis.Client) *RedisLocker {
+	return &RedisLocker{client: client, prefix: "lock:"}
+}
+
+// TryLock acquires the lock of key with SET NX, tagged with a random owner token
+func (l *RedisLocker) TryLock(ctx context.Context, key string, ttl time.Duration) (func(), bool, error) {
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return nil, false, err
+	}
+	owner := hex.EncodeToString(token)
+	lockKey := l.prefix + key
+
+	acquired, err := l.client.SetNX(ctx, lockKey, owner, ttl).Result()
+	if err != nil || !acquired {
+		return nil, false, err
+	}
+	unlock := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		if err := unlockScript.Run(ctx, l.client, []string{lockKey}, owner).Err(); err != nil {
+			log.Printf("Error releasing cache lock %s: %v", lockKey, err)
+		}
+	}
+	return unlock, true, nil
+}
+
+// LockerFromEnv returns a Redis locker when CACHE_DISTRIBUTED_LOCK is true and Redis is
+// connected, and nil otherwise. Call Connect first.
+func LockerFromEnv() Locker {
+	value := os.Getenv("CACHE_DISTRIBUTED_LOCK")
+	if value == "" {
+		return nil
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Invalid value for CACHE_DISTRIBUTED_LOCK ignored: %s (using false as default)", value)
+		return nil
+	}
+	if !enabled {
+		return nil
+	}
+	if Client == nil {
+		log.Println("Warning: CACHE_DISTRIBUTED_LOCK needs Redis, loads are only coalesced within each instance")
+		return nil
+	}
+	return NewRedisLocker(Client)
+}
@@ -0,0 +1,118 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// MemoryCache is an in-process Cache holding up to maxEntries values. When full, it evicts
+// the least recently used one. Values are stored JSON-encoded, like in Redis, so callers
+// never share them.
+type MemoryCache struct {
+	mutex      sync.Mutex
+	entries    map[string]*list.Element
+	lru        *list.List // Most recently used at the front
+	maxEntries int
+}
+
+// memoryEntry is a value of a MemoryCache with its expiration
+type memoryEntry struct {
+	key        string
+	data       []byte
+	expiration time.Time // Zero when the value never expires
+}
+
+// NewMemoryCache creates an in-process cache holding up to maxEntries values
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		maxEntries: maxEntries,
+	}
+}
+
+// Get retrieves an item from the cache
+func (c *MemoryCache) Get(ctx context.Context, key string, dest interface{}) (bool, error) {
+	c.mutex.Lock()
+	element, found := c.entries[key]
+	if !found {
+		c.mutex.Unlock()
+		return false, nil
+	}
+	entry := element.Value.(*memoryEntry)
+	if !entry.expiration.IsZero() && !time.Now().Before(entry.expiration) {
+		c.removeLocked(element)
+		c.mutex.Unlock()
+		return false, nil
+	}
+	c.lru.MoveToFront(element)
+	data := entry.data
+	c.mutex.Unlock()
+
+	if err := json.Unmarshal(data, dest); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Set stores an item in the cache. A zero expiration keeps it until it is evicted.
+func (c *MemoryCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	var expiresAt time.Time
+	if expiration > 0 {
+		expiresAt = time.Now().Add(expiration)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if element, found := c.entries[key]; found {
+		entry := element.Value.(*memoryEntry)
+		entry.data = data
+		entry.expiration = expiresAt
+		c.lru.MoveToFront(element)
+		return nil
+	}
+	for c.maxEntries > 0 && c.lru.Len() >= c.maxEntries {
+		c.removeLocked(c.lru.Back())
+	}
+	c.entries[key] = c.lru.PushFront(&memoryEntry{key: key, data: data, expiration: expiresAt})
+	return nil
+}
+
+// Delete removes an item from the cache
+func (c *MemoryCache) Delete(ctx context.Context, key string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if element, found := c.entries[key]; found {
+		c.removeLocked(element)
+	}
+	return nil
+}
+
+// Clear removes every item from the cache
+func (c *MemoryCache) Clear(ctx context.Context) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.entries = make(map[string]*list.Element)
+	c.lru.Init()
+	return nil
+}
+
+// Len returns the number of items in the cache, expired ones included until they are read
+func (c *MemoryCache) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.lru.Len()
+}
+
+// removeLocked removes an entry (assumes the lock is already obtained)
+func (c *MemoryCache) removeLocked(element *list.Element) {
+	c.lru.Remove(element)
+	delete(c.entries, element.Value.(*memoryEntry).key)
+}
@@ -38,8 +38,7 @@ func Connect() {
 	_, err := Client.Ping(ctx).Result()
 	if err != nil {
 		log.Printf("Warning: Could not connect to Redis: %v", err)
-		log.Println("Cache will be disabled. To enable caching, install Redis and run it on localhost:6379")
-		log.Println("The API will continue to function normally, but without the benefit of caching")
+		log.Println("Responses will only be cached in memory. To share the cache between instances, install Redis and run it on localhost:6379")
 		Client = nil
 		return
 	}
@@ -47,9 +46,20 @@ func Connect() {
 	log.Println("Redis connection established successfully")
 }
 
+// RedisCache is a Cache shared by all API replicas through Redis. With a nil client
+// caching is disabled: nothing is stored and every lookup misses.
+type RedisCache struct {
+	client *redis.Client
+}
+
+// NewRedisCache creates a Redis-backed cache
+func NewRedisCache(client *redis.Client) *RedisCache {
+	return &RedisCache{client: client}
+}
+
 // Set stores an item in the cache
-func Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
-	if Client == nil {
+func (c *RedisCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	if c.client == nil {
 		return nil // Cache disabled
 	}
 
@@ -59,26 +69,26 @@ func Set(ctx context.Context, key string, value interface{}, expiration time.Dur
 		return err
 	}
 	// Storing in Redis
-	return Client.Set(ctx, key, data, expiration).Err()
+	return c.client.Set(ctx, key, data, expiration).Err()
 }
 
 // Get retrieves an item from the cache
-func Get(ctx context.Context, key string, dest interface{}) (bool, error) {
-	if Client == nil {
+func (c *RedisCache) Get(ctx context.Context, key string, dest interface{}) (bool, error) {
+	if c.client == nil {
 		return false, nil // Cache disabled
 	}
 
-	// Buscando do Redis
-	data, err := Client.Get(ctx, key).Bytes()
+	// Fetching from Redis
+	data, err := c.client.Get(ctx, key).Bytes()
 	if err == redis.Nil {
-		// Item não encontrado no cache
+		// Item not found in the cache
 		return false, nil
 	} else if err != nil {
-		// Erro ao acessar o Redis
+		// Error accessing Redis
 		return false, err
 	}
 
-	// Convertendo de JSON para o tipo de destino
+	// Converting from JSON to the destination type
 	if err := json.Unmarshal(data, dest); err != nil {
 		return false, err
 	}
@@ -86,20 +96,20 @@ func Get(ctx context.Context, key string, dest interface{}) (bool, error) {
 	return true, nil
 }
 
-// Delete remove um item do cache
-func Delete(ctx context.Context, key string) error {
-	if Client == nil {
-		return nil // Cache desativado
+// Delete removes an item from the cache
+func (c *RedisCache) Delete(ctx context.Context, key string) error {
+	if c.client == nil {
+		return nil // Cache disabled
 	}
 
-	return Client.Del(ctx, key).Err()
+	return c.client.Del(ctx, key).Err()
 }
 
-// Clear limpa todo o cache
-func Clear(ctx context.Context) error {
-	if Client == nil {
-		return nil // Cache desativado
+// Clear removes every item from the cache
+func (c *RedisCache) Clear(ctx context.Context) error {
+	if c.client == nil {
+		return nil // Cache disabled
 	}
 
-	return Client.FlushAll(ctx).Err()
+	return c.client.FlushAll(ctx).Err()
 }
@@ -0,0 +1,165 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Tagger is implemented by caches whose entries can be invalidated by tag
+type Tagger interface {
+	// SetWithTags stores value under key like Set, and records key under each tag
+	SetWithTags(ctx context.Context, key string, value interface{}, expiration time.Duration, tags ...string) error
+	// InvalidateTags deletes every entry recorded under the tags
+	InvalidateTags(ctx context.Context, tags ...string) error
+	// TagGeneration returns a number that changes every time one of the tags is invalidated
+	TagGeneration(ctx context.Context, tags ...string) (int64, error)
+}
+
+// TagIndex records which keys are stored under each tag
+type TagIndex interface {
+	// Add records key under the tags until expiration
+	Add(ctx context.Context, key string, expiration time.Duration, tags ...string) error
+	// Pop returns the keys recorded under tag and forgets them, and moves the tag to its
+	// next generation
+	Pop(ctx context.Context, tag string) ([]string, error)
+	// Generation returns the sum of the generations of the tags
+	Generation(ctx context.Context, tags ...string) (int64, error)
+}
+
+// TaggedCache is a Cache whose entries can be invalidated by tag through a TagIndex
+type TaggedCache struct {
+	Cache
+	index TagIndex
+}
+
+// NewTaggedCache adds tag-based invalidation to a cache
+func NewTaggedCache(c Cache, index TagIndex) *TaggedCache {
+	return &TaggedCache{Cache: c, index: index}
+}
+
+// SetWithTags stores an item and records it under the tags
+func (c *TaggedCache) SetWithTags(ctx context.Context, key string, value interface{}, expiration time.Duration, tags ...string) error {
+	if err := c.Cache.Set(ctx, key, value, expiration); err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+	return c.index.Add(ctx, key, expiration, tags...)
+}
+
+// InvalidateTags deletes every item recorded under the tags
+func (c *TaggedCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	for _, tag := range tags {
+		keys, err := c.index.Pop(ctx, tag)
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if err := c.Cache.Delete(ctx, key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// TagGeneration returns a number that changes every time one of the tags is invalidated.
+// Loaders compare it before and after loading a value, so a value loaded across an
+// invalidation is not stored.
+func (c *TaggedCache) TagGeneration(ctx context.Context, tags ...string) (int64, error) {
+	return c.index.Generation(ctx, tags...)
+}
+
+// InvalidateTags deletes the entries of a cache recorded under the tags, if the cache
+// supports tags
+func InvalidateTags(ctx context.Context, c Cache, tags ...string) error {
+	if tagger, ok := c.(Tagger); ok {
+		return tagger.InvalidateTags(ctx, tags...)
+	}
+	return nil
+}
+
+// MemoryTagIndex is an in-process TagIndex, for caches local to one instance
+type MemoryTagIndex struct {
+	mutex       sync.Mutex
+	tags        map[string]*memoryTag
+	generations map[string]int64 // Number of times each tag was popped
+}
+
+// memoryTag holds the keys recorded under a tag with their expiration
+type memoryTag struct {
+	keys    map[string]time.Time // Zero when the key never expires
+	pruneAt int                  // Size at which expired keys are dropped
+}
+
+// minTagPruneSize is the size under which the expired keys of a tag are kept
+const minTagPruneSize = 64
+
+// NewMemoryTagIndex creates an in-process tag index
+func NewMemoryTagIndex() *MemoryTagIndex {
+	return &MemoryTagIndex{tags: make(map[string]*memoryTag), generations: make(map[string]int64)}
+}
+
+// Add records key under the tags until expiration
+func (i *MemoryTagIndex) Add(ctx context.Context, key string, expiration time.Duration, tags ...string) error {
+	var expiresAt time.Time
+	if expiration > 0 {
+		expiresAt = time.Now().Add(expiration)
+	}
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	for _, name := range tags {
+		tag, found := i.tags[name]
+		if !found {
+			tag = &memoryTag{keys: make(map[string]time.Time), pruneAt: minTagPruneSize}
+			i.tags[name] = tag
+		}
+		tag.keys[key] = expiresAt
+		if len(tag.keys) >= tag.pruneAt {
+			tag.prune()
+		}
+	}
+	return nil
+}
+
+// prune drops the expired keys of a tag, and waits for it to double before doing it again
+func (t *memoryTag) prune() {
+	now := time.Now()
+	for key, expiresAt := range t.keys {
+		if !expiresAt.IsZero() && !now.Before(expiresAt) {
+			delete(t.keys, key)
+		}
+	}
+	t.pruneAt = max(2*len(t.keys), minTagPruneSize)
+}
+
+// Pop returns the keys recorded under tag and forgets them
+func (i *MemoryTagIndex) Pop(ctx context.Context, name string) ([]string, error) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	i.generations[name]++
+	tag, found := i.tags[name]
+	if !found {
+		return nil, nil
+	}
+	delete(i.tags, name)
+	keys := make([]string, 0, len(tag.keys))
+	for key := range tag.keys {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// Generation returns the sum of the generations of the tags
+func (i *MemoryTagIndex) Generation(ctx context.Context, tags ...string) (int64, error) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	var generation int64
+	for _, tag := range tags {
+		generation += i.generations[tag]
+	}
+	return generation, nil
+}
@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// tagAddScript adds a key to the set of each tag and keeps the set until the last of its
+// keys expires. Expirations are in milliseconds, 0 for keys that never expire.
+var tagAddScript = redis.NewScript(`
+local expiration = tonumber(ARGV[2])
+for _, tag in ipairs(KEYS) do
+	local existed = redis.call('EXISTS', tag)
+	redis.call('SADD', tag, ARGV[1])
+	local ttl = redis.call('PTTL', tag)
+	if expiration == 0 then
+		redis.call('PERSIST', tag)
+	elseif existed == 0 or (ttl ~= -1 and ttl < expiration) then
+		redis.call('PEXPIRE', tag, expiration)
+	end
+end
+return 1
+`)
+
+// RedisTagIndex is a TagIndex shared by all API replicas, keeping the keys of each tag in
+// a Redis set
+type RedisTagIndex struct {
+	client           *redis.Client
+	prefix           string
+	generationPrefix string
+}
+
+// NewRedisTagIndex creates a Redis-backed tag index
+func NewRedisTagIndex(client *redis.Client) *RedisTagIndex {
+	return &RedisTagIndex{client: client, prefix: "tag:", generationPrefix: "tag-generation:"}
+}
+
+// Add records key under the tags until expiration
+func (i *RedisTagIndex) Add(ctx context.Context, key string, expiration time.Duration, tags ...string) error {
+	if i.client == nil {
+		return nil // Cache disabled
+	}
+	tagKeys := make([]string, len(tags))
+	for n, tag := range tags {
+		tagKeys[n] = i.prefix + tag
+	}
+	return tagAddScript.Run(ctx, i.client, tagKeys, key, expiration.Milliseconds()).Err()
+}
+
+// Pop returns the keys recorded under tag, forgets them and moves the tag to its next
+// generation, in a single transaction so a key recorded meanwhile is not forgotten
+// without being returned
+func (i *RedisTagIndex) Pop(ctx context.Context, tag string) ([]string, error) {
+	if i.client == nil {
+		return nil, nil // Cache disabled
+	}
+	var members *redis.StringSliceCmd
+	_, err := i.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		members = pipe.SMembers(ctx, i.prefix+tag)
+		pipe.Del(ctx, i.prefix+tag)
+		pipe.Incr(ctx, i.generationPrefix+tag)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return members.Val(), nil
+}
+
+// Generation returns the sum of the generations of the tags
+func (i *RedisTagIndex) Generation(ctx context.Context, tags ...string) (int64, error) {
+	if i.client == nil || len(tags) == 0 {
+		return 0, nil // Cache disabled
+	}
+	keys := make([]string, len(tags))
+	for n, tag := range tags {
+		keys[n] = i.generationPrefix + tag
+	}
+	values, err := i.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return 0, err
+	}
+	var generation int64
+	for _, value := range values {
+		if s, ok := value.(string); ok {
+			n, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return 0, err
+			}
+			generation += n
+		}
+	}
+	return generation, nil
+}
@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestTaggedCacheInvalidateTags checks that invalidating a tag deletes only its entries
+func TestTaggedCacheInvalidateTags(t *testing.T) {
+	ctx := context.Background()
+	c := NewTaggedCache(NewMemoryCache(10), NewMemoryTagIndex())
+	c.SetWithTags(ctx, "apods:range:2024-04-01:2024-05-31", testItem{"Range"}, time.Hour, "range")
+	c.SetWithTags(ctx, "search:nebula", testItem{"Search"}, time.Hour, "search")
+	c.SetWithTags(ctx, "apod:date:2024-05-01", testItem{"M31"}, time.Hour, "apod:2024-05-01")
+	c.Set(ctx, "untagged", testItem{"Untagged"}, time.Hour)
+
+	if err := c.InvalidateTags(ctx, "range", "apod:2024-05-01"); err != nil {
+		t.Fatal(err)
+	}
+
+	var item testItem
+	for key, expected := range map[string]bool{
+		"apods:range:2024-04-01:2024-05-31": false,
+		"apod:date:2024-05-01":              false,
+		"search:nebula":                     true,
+		"untagged":                          true,
+	} {
+		if found, _ := c.Get(ctx, key, &item); found != expected {
+			t.Errorf("Expected %s to be cached: %v, got %v", key, expected, found)
+		}
+	}
+
+	// A tag is forgotten once invalidated, entries stored later are recorded again
+	c.SetWithTags(ctx, "apods:range:2024-05-01:2024-05-31", testItem{"Range"}, time.Hour, "range")
+	c.InvalidateTags(ctx, "range")
+	if found, _ := c.Get(ctx, "apods:range:2024-05-01:2024-05-31", &item); found {
+		t.Error("Expected the range stored after the first invalidation to be invalidated")
+	}
+}
+
+// TestLoaderTags checks that the loader records its values under the tags of the policy
+func TestLoaderTags(t *testing.T) {
+	ctx := context.Background()
+	c := NewTaggedCache(NewMemoryCache(10), NewMemoryTagIndex())
+	loader := NewLoader(c, nil)
+	policy := Policy{TTL: time.Hour, Tags: []string{"latest"}}
+	loads := 0
+	load := func(ctx context.Context) (interface{}, error) {
+		loads++
+		return testItem{"M31"}, nil
+	}
+
+	var item testItem
+	loader.Fetch(ctx, "apod:latest", policy.WithTags("apod:2024-05-01"), &item, load)
+	if len(policy.Tags) != 1 {
+		t.Errorf("Expected WithTags not to change the policy, got %v", policy.Tags)
+	}
+	if err := InvalidateTags(ctx, c, "apod:2024-05-01"); err != nil {
+		t.Fatal(err)
+	}
+	if status, _ := loader.Fetch(ctx, "apod:latest", policy, &item, load); status != StatusMiss || loads != 2 {
+		t.Errorf("Expected the invalidated value to be loaded again, got %s after %d loads", status, loads)
+	}
+}
+
+// TestMemoryTagIndexPrune checks that expired keys don't accumulate under a tag
+func TestMemoryTagIndexPrune(t *testing.T) {
+	ctx := context.Background()
+	index := NewMemoryTagIndex()
+	for i := 0; i < minTagPruneSize; i++ {
+		index.Add(ctx, "search:"+string(rune('a'+i)), time.Nanosecond, "search")
+	}
+	time.Sleep(time.Millisecond)
+	index.Add(ctx, "search:nebula", time.Hour, "search")
+
+	keys, _ := index.Pop(ctx, "search")
+	if len(keys) > minTagPruneSize {
+		t.Errorf("Expected expired keys to be pruned, got %d keys", len(keys))
+	}
+}
@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// TieredCache is a two-tier Cache: a fast local L1 (usually a MemoryCache) in front of a
+// shared L2 (usually Redis). Reads try L1 first and copy L2 hits into it; writes and deletes
+// go to both. L1 values are kept for at most l1TTL, which bounds how long an instance can
+// serve a value another instance has replaced or deleted in L2.
+type TieredCache struct {
+	l1    Cache
+	l2    Cache
+	l1TTL time.Duration
+}
+
+// NewTieredCache creates a two-tier cache keeping L1 values for at most l1TTL
+func NewTieredCache(l1, l2 Cache, l1TTL time.Duration) *TieredCache {
+	return &TieredCache{l1: l1, l2: l2, l1TTL: l1TTL}
+}
+
+// l1Expiration caps an expiration to the L1 time to live
+func (c *TieredCache) l1Expiration(expiration time.Duration) time.Duration {
+	if expiration <= 0 || expiration > c.l1TTL {
+		return c.l1TTL
+	}
+	return expiration
+}
+
+// Get retrieves an item from L1, then from L2
+func (c *TieredCache) Get(ctx context.Context, key string, dest interface{}) (bool, error) {
+	if found, err := c.l1.Get(ctx, key, dest); err == nil && found {
+		return true, nil
+	}
+	found, err := c.l2.Get(ctx, key, dest)
+	if err != nil || !found {
+		return false, err
+	}
+	// Keep it locally for the next lookups
+	if err := c.l1.Set(ctx, key, dest, c.l1TTL); err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
+// Set stores an item in both tiers
+func (c *TieredCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	if err := c.l1.Set(ctx, key, value, c.l1Expiration(expiration)); err != nil {
+		return err
+	}
+	return c.l2.Set(ctx, key, value, expiration)
+}
+
+// Delete removes an item from both tiers
+func (c *TieredCache) Delete(ctx context.Context, key string) error {
+	if err := c.l1.Delete(ctx, key); err != nil {
+		return err
+	}
+	return c.l2.Delete(ctx, key)
+}
+
+// Clear removes every item from both tiers
+func (c *TieredCache) Clear(ctx context.Context) error {
+	if err := c.l1.Clear(ctx); err != nil {
+		return err
+	}
+	return c.l2.Clear(ctx)
+}
@@ -11,7 +11,18 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-var ApodCollection *mongo.Collection
+var (
+	// Database is the MongoDB database used by the API
+	Database *mongo.Database
+	// ApodCollection stores the APOD documents
+	ApodCollection *mongo.Collection
+	// APIKeyCollection stores the issued API keys
+	APIKeyCollection *mongo.Collection
+	// TranslationCollection stores the permanent APOD translations
+	TranslationCollection *mongo.Collection
+	// GlossaryCollection stores the translation glossary
+	GlossaryCollection *mongo.Collection
+)
 
 func Connect() { // Load environment variables
 	err := godotenv.Load()
@@ -31,6 +42,18 @@ func Connect() { // Load environment variables
 	if err != nil {
 		log.Fatal(err)
 	}
-	ApodCollection = client.Database(dbName).Collection(collectionName)
+	Database = client.Database(dbName)
+	ApodCollection = Database.Collection(collectionName)
+	APIKeyCollection = Database.Collection(collectionNameOrDefault("MONGODB_APIKEYS_COLLECTION", "api_keys"))
+	TranslationCollection = Database.Collection(collectionNameOrDefault("MONGODB_TRANSLATIONS_COLLECTION", "apod_translations"))
+	GlossaryCollection = Database.Collection(collectionNameOrDefault("MONGODB_GLOSSARY_COLLECTION", "glossary"))
 	log.Println("MongoDB connected successfully.")
 }
+
+// collectionNameOrDefault reads a collection name from the environment, falling back to a default
+func collectionNameOrDefault(envVar, defaultName string) string {
+	if name := os.Getenv(envVar); name != "" {
+		return name
+	}
+	return defaultName
+}
@@ -15,39 +15,47 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/apod": {
+        "/admin/glossary": {
             "get": {
-                "description": "Returns the most recent Astronomy Picture of the Day",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns the per-language term translations and the do-not-translate terms applied to machine translations",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "APOD"
+                    "Admin"
+                ],
+                "summary": "List glossary entries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token with the admin role",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key with the admin scope",
+                        "name": "X-API-Key",
+                        "in": "header"
+                    }
                 ],
-                "summary": "Get the most recent APOD",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.Apod"
+                            "$ref": "#/definitions/handlers.GlossaryResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/i18n.ErrorResponse"
                         }
                     }
                 }
             },
             "post": {
-                "description": "Fetches the most recent APOD from NASA API and adds it to the database",
+                "description": "Adds a term with a fixed translation for one language, or a do-not-translate term when no language is given. Applies to new machine translations.",
                 "consumes": [
                     "application/json"
                 ],
@@ -55,95 +63,158 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "APOD"
+                    "Admin"
                 ],
-                "summary": "Adds new APOD from NASA",
+                "summary": "Add a glossary entry",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Internal API token",
-                        "name": "X-API-Token",
-                        "in": "header",
-                        "required": true
+                        "description": "Bearer token with the admin role",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key with the admin scope",
+                        "name": "X-API-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Glossary entry",
+                        "name": "entry",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/i18n.GlossaryEntry"
+                        }
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/i18n.GlossaryEntry"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/i18n.ErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/admin/glossary/{id}": {
+            "delete": {
+                "description": "Removes a term from the glossary",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete a glossary entry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token with the admin role",
+                        "name": "Authorization",
+                        "in": "header"
                     },
-                    "409": {
-                        "description": "Conflict",
+                    {
+                        "type": "string",
+                        "description": "API key with the admin scope",
+                        "name": "X-API-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Glossary entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/i18n.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/i18n.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/apod/{date}": {
+        "/admin/keys": {
             "get": {
-                "description": "Returns the astronomy picture of the day for the specified date",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns every issued API key, including revoked ones, with today's and this month's usage",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "APOD"
+                    "Admin"
                 ],
-                "summary": "Gets an APOD by specific date",
+                "summary": "List API keys",
                 "parameters": [
                     {
                         "type": "string",
-                        "example": "\"2023-01-15\"",
-                        "description": "Date in YYYY-MM-DD format",
-                        "name": "date",
-                        "in": "path",
-                        "required": true
+                        "description": "Bearer token with the admin role",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key with the admin scope",
+                        "name": "X-API-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.Apod"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.APIKeyReport"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Error getting APOD",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/i18n.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/i18n.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/i18n.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/apods": {
-            "get": {
-                "description": "Returns all registered Astronomy Pictures of the Day",
+            },
+            "post": {
+                "description": "Issues a new API key with scopes and quotas. The plaintext key is only returned once.",
                 "consumes": [
                     "application/json"
                 ],
@@ -151,321 +222,1612 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "APODs"
+                    "Admin"
+                ],
+                "summary": "Create an API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token with the admin role",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key with the admin scope",
+                        "name": "X-API-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Key settings",
+                        "name": "key",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateAPIKeyRequest"
+                        }
+                    }
                 ],
-                "summary": "Get all APODs",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/handlers.AllApodsResponse"
+                            "$ref": "#/definitions/handlers.APIKeyIssuedResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/i18n.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/i18n.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/i18n.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/apods/date-range": {
-            "get": {
-                "description": "Returns the Astronomy Pictures of the Day within a specified date range",
-                "consumes": [
-                    "application/json"
-                ],
+        "/admin/keys/{id}": {
+            "delete": {
+                "description": "Permanently disables an API key",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "APODs"
+                    "Admin"
                 ],
-                "summary": "Get APODs by date range",
+                "summary": "Revoke an API key",
                 "parameters": [
                     {
                         "type": "string",
-                        "example": "\"2023-01-01\"",
-                        "description": "Start date (YYYY-MM-DD format)",
-                        "name": "start",
-                        "in": "query"
+                        "description": "Bearer token with the admin role",
+                        "name": "Authorization",
+                        "in": "header"
                     },
                     {
                         "type": "string",
-                        "example": "\"2023-01-31\"",
-                        "description": "End date (YYYY-MM-DD format)",
-                        "name": "end",
-                        "in": "query"
+                        "description": "API key with the admin scope",
+                        "name": "X-API-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ApodsDateRangeResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/i18n.ErrorResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/i18n.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/apods/search": {
-            "get": {
-                "description": "Search APODs with filters, pagination and sorting",
-                "consumes": [
-                    "application/json"
-                ],
+        "/admin/keys/{id}/rotate": {
+            "post": {
+                "description": "Generates a new secret for the key. The old secret stops working immediately on this instance and within a minute on the others.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "APODs"
+                    "Admin"
                 ],
-                "summary": "Advanced APOD search",
+                "summary": "Rotate an API key",
                 "parameters": [
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "example": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "maximum": 200,
-                        "minimum": 1,
-                        "type": "integer",
-                        "example": 20,
-                        "description": "Items per page (1-200)",
-                        "name": "perPage",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "image",
-                            "video",
-                            "any"
-                        ],
-                        "type": "string",
-                        "example": "image",
-                        "description": "Media type (image, video or any)",
-                        "name": "mediaType",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "nebula",
-                        "description": "Text to search in title and explanation",
-                        "name": "search",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "example": "2023-01-01",
-                        "description": "Start date (YYYY-MM-DD format)",
-                        "name": "startDate",
-                        "in": "query"
+                        "description": "Bearer token with the admin role",
+                        "name": "Authorization",
+                        "in": "header"
                     },
                     {
                         "type": "string",
-                        "example": "2023-01-31",
-                        "description": "End date (YYYY-MM-DD format)",
-                        "name": "endDate",
-                        "in": "query"
+                        "description": "API key with the admin scope",
+                        "name": "X-API-Key",
+                        "in": "header"
                     },
                     {
-                        "enum": [
-                            "asc",
-                            "desc"
-                        ],
                         "type": "string",
-                        "example": "desc",
-                        "description": "Sort order (asc or desc)",
-                        "name": "sort",
-                        "in": "query"
+                        "description": "API key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.SearchResponse"
+                            "$ref": "#/definitions/handlers.APIKeyIssuedResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/i18n.ErrorResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/i18n.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/languages": {
+        "/admin/translations/status": {
             "get": {
-                "description": "Returns the list of languages supported by the AstroVista API",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns the translation providers in fallback order with their circuit breaker state, health counters, last error and cache statistics",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Configuration"
+                    "Admin"
                 ],
-                "summary": "List supported languages",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/handlers.LanguageInfo"
+                "summary": "Detailed translation provider status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token with the admin role",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key with the admin scope",
+                        "name": "X-API-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AdminTranslationStatusResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/translations/usage": {
+            "get": {
+                "description": "Returns the characters sent to each translation provider per day and language, the translation cache hits and misses, and the state of the monthly character budgets",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Translation usage report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token with the admin role",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key with the admin scope",
+                        "name": "X-API-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2025-01-01\"",
+                        "description": "First day (YYYY-MM-DD), defaults to the first day of the month",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2025-01-31\"",
+                        "description": "Last day (YYYY-MM-DD), defaults to today",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/i18n.TranslationUsageReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/i18n.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/i18n.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/translations/{date}/{lang}": {
+            "get": {
+                "description": "Returns the original text of every translatable field of an APOD with its stored machine translation and editorial state",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "View APOD translations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token with the admin role",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key with the admin scope",
+                        "name": "X-API-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "APOD date (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language code",
+                        "name": "lang",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.TranslationFieldReview"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/i18n.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/i18n.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/i18n.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/translations/{date}/{lang}/{field}": {
+            "put": {
+                "description": "Saves a corrected translation of an APOD field as a draft. Readers keep getting the current translation until the draft is approved.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Edit an APOD translation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token with the admin role",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key with the admin scope",
+                        "name": "X-API-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "APOD date (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language code",
+                        "name": "lang",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field (title, explanation or copyright)",
+                        "name": "field",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Corrected translation",
+                        "name": "translation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TranslationDraftRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/i18n.StoredTranslation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/i18n.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/i18n.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/i18n.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/translations/{date}/{lang}/{field}/approve": {
+            "post": {
+                "description": "Approves the pending draft of an APOD field. Approved human translations always take precedence over machine translations.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Approve an APOD translation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token with the admin role",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key with the admin scope",
+                        "name": "X-API-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "APOD date (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language code",
+                        "name": "lang",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field (title, explanation or copyright)",
+                        "name": "field",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/i18n.StoredTranslation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/i18n.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/i18n.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/i18n.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/apod": {
+            "get": {
+                "description": "Returns the most recent Astronomy Picture of the Day",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "APOD"
+                ],
+                "summary": "Get the most recent APOD",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Apod"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/i18n.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Fetches the most recent APOD from NASA API (or the mirror set by APOD_SOURCE_URL) and adds it to the database, detecting the language it is written in",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "APOD"
+                ],
+                "summary": "Adds new APOD from NASA",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token with the ingest role",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key with the ingest scope",
+                        "name": "X-API-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Internal API token (deprecated)",
+                        "name": "X-API-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/i18n.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/i18n.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/i18n.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/i18n.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/apod/{date}": {
+            "get": {
+                "description": "Returns the astronomy picture of the day for the specified date",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "APOD"
+                ],
+                "summary": "Gets an APOD by specific date",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"2023-01-15\"",
+                        "description": "Date in YYYY-MM-DD format",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Apod"
+                        }
+                    },
+                    "400": {
+                        "description": "Error getting APOD",
+                        "schema": {
+                            "$ref": "#/definitions/i18n.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/apods": {
+            "get": {
+                "description": "Returns all registered Astronomy Pictures of the Day",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "APODs"
+                ],
+                "summary": "Get all APODs",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AllApodsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/i18n.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/i18n.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/apods/date-range": {
+            "get": {
+                "description": "Returns the Astronomy Pictures of the Day within a specified date range",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "APODs"
+                ],
+                "summary": "Get APODs by date range",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"2023-01-01\"",
+                        "description": "Start date (YYYY-MM-DD format)",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2023-01-31\"",
+                        "description": "End date (YYYY-MM-DD format)",
+                        "name": "end",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ApodsDateRangeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/i18n.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/i18n.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/apods/search": {
+            "get": {
+                "description": "Search APODs with filters, pagination and sorting",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "APODs"
+                ],
+                "summary": "Advanced APOD search",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 200,
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 20,
+                        "description": "Items per page (1-200)",
+                        "name": "perPage",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "image",
+                            "video",
+                            "any"
+                        ],
+                        "type": "string",
+                        "example": "image",
+                        "description": "Media type (image, video or any)",
+                        "name": "mediaType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "nebula",
+                        "description": "Text to search in title and explanation",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2023-01-01",
+                        "description": "Start date (YYYY-MM-DD format)",
+                        "name": "startDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2023-01-31",
+                        "description": "End date (YYYY-MM-DD format)",
+                        "name": "endDate",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "example": "desc",
+                        "description": "Sort order (asc or desc)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/i18n.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/i18n.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/languages": {
+            "get": {
+                "description": "Returns the languages supported by the AstroVista API, discovered from the locale files,\nwith their text direction and how complete their translation of the API messages is",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Configuration"
+                ],
+                "summary": "List supported languages",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/i18n.Language"
                             }
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "handlers.AllApodsResponse": {
+        },
+        "/translation/status": {
+            "get": {
+                "description": "Returns the translation providers in fallback order with their circuit breaker state and the time of their last success, last failure and next retry",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Configuration"
+                ],
+                "summary": "Translation provider status",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TranslationStatusResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "apikeys.APIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Creation time",
+                    "type": "string"
+                },
+                "daily_quota": {
+                    "description": "Maximum number of requests per day (0 = unlimited)\nexample: 10000",
+                    "type": "integer"
+                },
+                "id": {
+                    "description": "MongoDB ID\nexample: 665f1f77bcf86cd799439011",
+                    "type": "string"
+                },
+                "monthly_quota": {
+                    "description": "Maximum number of requests per month (0 = unlimited)\nexample: 200000",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Human readable name of the key owner\nexample: Mobile app",
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "First characters of the key, to identify it without revealing it\nexample: av_Xy12ab",
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "description": "Revocation time (revoked keys are rejected)",
+                    "type": "string"
+                },
+                "rotated_at": {
+                    "description": "Last rotation time",
+                    "type": "string"
+                },
+                "scopes": {
+                    "description": "Scopes granted to the key\nexample: [\"read\"]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "apikeys.Usage": {
+            "type": "object",
+            "properties": {
+                "day": {
+                    "description": "Requests made today (UTC)\nexample: 120",
+                    "type": "integer"
+                },
+                "month": {
+                    "description": "Requests made this month (UTC)\nexample: 3400",
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.APIKeyIssuedResponse": {
+            "type": "object",
+            "properties": {
+                "api_key": {
+                    "description": "Stored key metadata",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/apikeys.APIKey"
+                        }
+                    ]
+                },
+                "key": {
+                    "description": "Plaintext API key, to be sent in the X-API-Key header\nexample: av_Xy12abCdEf...",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.APIKeyReport": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Creation time",
+                    "type": "string"
+                },
+                "daily_quota": {
+                    "description": "Maximum number of requests per day (0 = unlimited)\nexample: 10000",
+                    "type": "integer"
+                },
+                "id": {
+                    "description": "MongoDB ID\nexample: 665f1f77bcf86cd799439011",
+                    "type": "string"
+                },
+                "monthly_quota": {
+                    "description": "Maximum number of requests per month (0 = unlimited)\nexample: 200000",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Human readable name of the key owner\nexample: Mobile app",
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "First characters of the key, to identify it without revealing it\nexample: av_Xy12ab",
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "description": "Revocation time (revoked keys are rejected)",
+                    "type": "string"
+                },
+                "rotated_at": {
+                    "description": "Last rotation time",
+                    "type": "string"
+                },
+                "scopes": {
+                    "description": "Scopes granted to the key\nexample: [\"read\"]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "usage": {
+                    "description": "Current usage counters",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/apikeys.Usage"
+                        }
+                    ]
+                }
+            }
+        },
+        "handlers.AdminTranslationStatusResponse": {
+            "type": "object",
+            "properties": {
+                "providers": {
+                    "description": "Providers in fallback order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/i18n.ProviderStatus"
+                    }
+                },
+                "status": {
+                    "description": "Overall state, as in TranslationStatusResponse\nexample: ok",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.AllApodsResponse": {
+            "type": "object",
+            "properties": {
+                "apods": {
+                    "description": "List of APODs",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.Apod"
+                    }
+                },
+                "count": {
+                    "description": "Total number of APODs found\nexample: 15",
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.Apod": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "description": "MongoDB ID\nexample: 507f1f77bcf86cd799439011",
+                    "type": "string"
+                },
+                "date": {
+                    "description": "Date in string format (e.g. \"1995-06-16\")\nexample: 2023-01-15\nformat: date",
+                    "type": "string"
+                },
+                "explanation": {
+                    "description": "Explanation of the astronomy picture of the day\nexample: A beautiful nebula captured by the Hubble telescope",
+                    "type": "string"
+                },
+                "hdurl": {
+                    "description": "URL of the high-definition image\nexample: https://apod.nasa.gov/apod/image/2301/M31_HubbleSpitzerGendler_960.jpg\nformat: uri",
+                    "type": "string"
+                },
+                "media_type": {
+                    "description": "Media type (image or video)\nexample: image\nenum: image,video",
+                    "type": "string"
+                },
+                "service_version": {
+                    "description": "API service version\nexample: v1",
+                    "type": "string"
+                },
+                "source_lang": {
+                    "description": "Language the APOD is written in, detected when it is added (English when missing)\nexample: en",
+                    "type": "string"
+                },
+                "title": {
+                    "description": "Title of the astronomy picture of the day\nexample: Andromeda Galaxy",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "URL of the standard resolution image\nexample: https://apod.nasa.gov/apod/image/2301/M31_HubbleSpitzerGendler_960.jpg\nformat: uri",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ApodsDateRangeResponse": {
+            "type": "object",
+            "properties": {
+                "apods": {
+                    "description": "List of APODs",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.Apod"
+                    }
+                },
+                "count": {
+                    "description": "Total number of APODs found\nexample: 7",
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.CreateAPIKeyRequest": {
+            "type": "object",
+            "properties": {
+                "daily_quota": {
+                    "description": "Maximum number of requests per day (0 = unlimited, omitted = default)\nexample: 10000",
+                    "type": "integer"
+                },
+                "monthly_quota": {
+                    "description": "Maximum number of requests per month (0 = unlimited, omitted = default)\nexample: 200000",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Human readable name of the key owner\nexample: Mobile app",
+                    "type": "string"
+                },
+                "scopes": {
+                    "description": "Scopes to grant (read, ingest, admin)\nexample: [\"read\"]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handlers.GlossaryResponse": {
             "type": "object",
             "properties": {
-                "count": {
-                    "description": "Total number of APODs found\nexample: 15",
+                "entries": {
+                    "description": "Glossary entries",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/i18n.GlossaryEntry"
+                    }
+                },
+                "version": {
+                    "description": "Glossary version, changes whenever an entry is added or removed\nexample: 3f2a9c1b7d4e",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.SearchResponse": {
+            "type": "object",
+            "properties": {
+                "page": {
+                    "description": "Current page number\nexample: 1",
                     "type": "integer"
                 },
-                "items": {
-                    "description": "List of APODs",
+                "per_page": {
+                    "description": "Items per page\nexample: 20",
+                    "type": "integer"
+                },
+                "results": {
+                    "description": "Search results",
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/handlers.Apod"
                     }
+                },
+                "total_pages": {
+                    "description": "Total number of available pages\nexample: 3",
+                    "type": "integer"
+                },
+                "total_results": {
+                    "description": "Total number of results found\nexample: 42",
+                    "type": "integer"
                 }
             }
         },
-        "handlers.Apod": {
+        "handlers.TranslationDraftRequest": {
             "type": "object",
             "properties": {
-                "_id": {
-                    "description": "MongoDB ID\nexample: 507f1f77bcf86cd799439011",
+                "text": {
+                    "description": "Corrected translation\nexample: Galáxia de Andrômeda",
                     "type": "string"
-                },
-                "date": {
-                    "description": "Date in string format (e.g. \"1995-06-16\")\nexample: 2023-01-15\nformat: date",
+                }
+            }
+        },
+        "handlers.TranslationFieldReview": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "description": "Field name\nexample: title",
                     "type": "string"
                 },
-                "explanation": {
-                    "description": "Explanation of the astronomy picture of the day\nexample: A beautiful nebula captured by the Hubble telescope",
+                "original": {
+                    "description": "Original text, in the language the APOD is written in\nexample: Andromeda Galaxy",
                     "type": "string"
                 },
-                "hdurl": {
-                    "description": "URL of the high-definition image\nexample: https://apod.nasa.gov/apod/image/2301/M31_HubbleSpitzerGendler_960.jpg\nformat: uri",
+                "translation": {
+                    "description": "Stored translation, if any",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/i18n.StoredTranslation"
+                        }
+                    ]
+                }
+            }
+        },
+        "handlers.TranslationProviderHealth": {
+            "type": "object",
+            "properties": {
+                "last_failure": {
                     "type": "string"
                 },
-                "media_type": {
-                    "description": "Media type (image or video)\nexample: image\nenum: image,video",
+                "last_success": {
                     "type": "string"
                 },
-                "service_version": {
-                    "description": "API service version\nexample: v1",
+                "name": {
+                    "description": "Provider name\nexample: deepl",
                     "type": "string"
                 },
-                "title": {
-                    "description": "Title of the astronomy picture of the day\nexample: Andromeda Galaxy",
+                "retry_at": {
                     "type": "string"
                 },
-                "url": {
-                    "description": "URL of the standard resolution image\nexample: https://apod.nasa.gov/apod/image/2301/M31_HubbleSpitzerGendler_960.jpg\nformat: uri",
+                "state": {
+                    "description": "Circuit breaker state: closed, open or half-open\nexample: closed",
                     "type": "string"
                 }
             }
         },
-        "handlers.ApodsDateRangeResponse": {
+        "handlers.TranslationStatusResponse": {
             "type": "object",
             "properties": {
-                "count": {
-                    "description": "Total number of APODs found\nexample: 7",
-                    "type": "integer"
-                },
-                "items": {
-                    "description": "List of APODs",
+                "providers": {
+                    "description": "Providers in fallback order",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/handlers.Apod"
+                        "$ref": "#/definitions/handlers.TranslationProviderHealth"
                     }
+                },
+                "status": {
+                    "description": "Overall state: \"ok\" when the first provider is available, \"degraded\" when a\nfallback is in use and \"unavailable\" when every circuit is open\nexample: ok",
+                    "type": "string"
+                }
+            }
+        },
+        "i18n.ErrorCode": {
+            "type": "string",
+            "enum": [
+                "apod_not_found",
+                "apod_already_exists",
+                "no_documents_found",
+                "search_no_results",
+                "date_range_no_results",
+                "invalid_date",
+                "invalid_media_type",
+                "date_range_error",
+                "nasa_api_error",
+                "unsupported_language",
+                "invalid_request_body",
+                "invalid_id",
+                "rate_limit_exceeded",
+                "unauthorized",
+                "forbidden",
+                "invalid_api_key",
+                "quota_exceeded",
+                "auth_error",
+                "api_key_name_required",
+                "api_key_not_found",
+                "api_key_error",
+                "invalid_translation_field",
+                "translation_text_required",
+                "translation_not_found",
+                "no_draft_to_approve",
+                "translation_error",
+                "glossary_entry_not_found",
+                "invalid_glossary_entry",
+                "glossary_error",
+                "database_error",
+                "general_error"
+            ],
+            "x-enum-varnames": [
+                "CodeAPODNotFound",
+                "CodeAPODAlreadyExists",
+                "CodeNoDocumentsFound",
+                "CodeSearchNoResults",
+                "CodeDateRangeNoResults",
+                "CodeInvalidDate",
+                "CodeInvalidMediaType",
+                "CodeDateRangeError",
+                "CodeNASAAPIError",
+                "CodeUnsupportedLanguage",
+                "CodeInvalidRequestBody",
+                "CodeInvalidID",
+                "CodeRateLimitExceeded",
+                "CodeUnauthorized",
+                "CodeForbidden",
+                "CodeInvalidAPIKey",
+                "CodeQuotaExceeded",
+                "CodeAuthError",
+                "CodeAPIKeyNameRequired",
+                "CodeAPIKeyNotFound",
+                "CodeAPIKeyError",
+                "CodeInvalidTranslationField",
+                "CodeTranslationTextRequired",
+                "CodeTranslationNotFound",
+                "CodeNoDraftToApprove",
+                "CodeTranslationError",
+                "CodeGlossaryEntryNotFound",
+                "CodeInvalidGlossaryEntry",
+                "CodeGlossaryError",
+                "CodeDatabaseError",
+                "CodeGeneralError"
+            ]
+        },
+        "i18n.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Stable, machine-readable error code\nexample: apod_not_found",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/i18n.ErrorCode"
+                        }
+                    ]
+                },
+                "details": {
+                    "description": "Technical details, in English",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Error message in the language of the request\nexample: Document not found! Please check the date format (YYYY-MM-DD).",
+                    "type": "string"
+                }
+            }
+        },
+        "i18n.GlossaryEntry": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lang": {
+                    "description": "Target language; empty for a do-not-translate term\nexample: pt-BR",
+                    "type": "string"
+                },
+                "term": {
+                    "description": "English term, matched case-sensitively on word boundaries\nexample: redshift",
+                    "type": "string"
+                },
+                "translation": {
+                    "description": "Fixed translation; empty keeps the term as-is\nexample: desvio para o vermelho",
+                    "type": "string"
                 }
             }
         },
-        "handlers.LanguageInfo": {
+        "i18n.Language": {
             "type": "object",
             "properties": {
                 "code": {
+                    "description": "Language code, the name of the locale file\nexample: pt-BR",
                     "type": "string"
                 },
+                "completeness": {
+                    "description": "Share of the English messages translated in the locale, the date format counting\nas one more (0 to 1)\nexample: 1",
+                    "type": "number"
+                },
+                "dateFormat": {
+                    "description": "Whether CLDR has a long date format for the language; dates are formatted in\nEnglish otherwise\nexample: true",
+                    "type": "boolean"
+                },
+                "dir": {
+                    "description": "Text direction\nexample: ltr",
+                    "type": "string",
+                    "enum": [
+                        "ltr",
+                        "rtl"
+                    ]
+                },
+                "messages": {
+                    "description": "Number of English messages translated in the locale\nexample: 34",
+                    "type": "integer"
+                },
                 "name": {
+                    "description": "English name\nexample: Brazilian Portuguese",
                     "type": "string"
                 },
                 "nativeName": {
+                    "description": "Name in the language itself\nexample: Português do Brasil",
                     "type": "string"
                 }
             }
         },
-        "handlers.SearchResponse": {
+        "i18n.ProviderStatus": {
             "type": "object",
             "properties": {
-                "page": {
-                    "description": "Current page number\nexample: 1",
+                "cache": {
+                    "description": "Translation cache of the provider, omitted for providers without a cache",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/i18n.TranslationCacheStats"
+                        }
+                    ]
+                },
+                "consecutive_failures": {
                     "type": "integer"
                 },
-                "per_page": {
-                    "description": "Items per page\nexample: 20",
+                "last_error": {
+                    "type": "string"
+                },
+                "last_failure": {
+                    "type": "string"
+                },
+                "last_success": {
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Provider name\nexample: deepl",
+                    "type": "string"
+                },
+                "retry_at": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "total_failures": {
                     "type": "integer"
                 },
-                "results": {
-                    "description": "Search results",
+                "total_successes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "i18n.StoredTranslation": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "description": "APOD date the translation belongs to",
+                    "type": "string"
+                },
+                "field": {
+                    "description": "Translated field (title, explanation or copyright)",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lang": {
+                    "description": "Target language",
+                    "type": "string"
+                },
+                "machine_text": {
+                    "description": "Machine translation replaced by an approved human translation",
+                    "type": "string"
+                },
+                "provider": {
+                    "description": "Provider that produced a machine translation",
+                    "type": "string"
+                },
+                "review": {
+                    "description": "Editorial state, if an editor worked on this field",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/i18n.TranslationReview"
+                        }
+                    ]
+                },
+                "source": {
+                    "description": "Where the served text comes from (machine or human)",
+                    "type": "string"
+                },
+                "source_hash": {
+                    "description": "SHA-256 of the English text, so edits to the APOD invalidate the translation",
+                    "type": "string"
+                },
+                "text": {
+                    "description": "Translated text",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "i18n.TranslationBudgetStatus": {
+            "type": "object",
+            "properties": {
+                "exceeded": {
+                    "description": "Whether translations in this scope are suspended until next month",
+                    "type": "boolean"
+                },
+                "limit": {
+                    "description": "Characters allowed per month\nexample: 500000",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Provider or language the budget applies to\nexample: deepl",
+                    "type": "string"
+                },
+                "remaining": {
+                    "description": "example: 376544",
+                    "type": "integer"
+                },
+                "scope": {
+                    "description": "Budget scope (total, provider or lang)\nexample: provider",
+                    "type": "string"
+                },
+                "used": {
+                    "description": "Characters used this month\nexample: 123456",
+                    "type": "integer"
+                }
+            }
+        },
+        "i18n.TranslationCacheStats": {
+            "type": "object",
+            "properties": {
+                "evictions": {
+                    "description": "Entries evicted to make room for new ones\nexample: 12",
+                    "type": "integer"
+                },
+                "hit_ratio": {
+                    "description": "Share of lookups answered by the cache (0 to 1)\nexample: 0.89",
+                    "type": "number"
+                },
+                "hits": {
+                    "description": "Lookups answered by the cache (memory or Redis)\nexample: 1520",
+                    "type": "integer"
+                },
+                "max_size": {
+                    "description": "Maximum entries in memory\nexample: 1000",
+                    "type": "integer"
+                },
+                "misses": {
+                    "description": "Lookups not found in the cache\nexample: 180",
+                    "type": "integer"
+                },
+                "redis": {
+                    "description": "Whether translations are also stored in Redis",
+                    "type": "boolean"
+                },
+                "size": {
+                    "description": "Entries in memory\nexample: 820",
+                    "type": "integer"
+                }
+            }
+        },
+        "i18n.TranslationReview": {
+            "type": "object",
+            "properties": {
+                "approved_at": {
+                    "type": "string"
+                },
+                "approved_by": {
+                    "description": "Who approved the text",
+                    "type": "string"
+                },
+                "editor": {
+                    "description": "Who last edited the text",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Review state (draft or approved)",
+                    "type": "string"
+                },
+                "text": {
+                    "description": "Text written by the editor",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "i18n.TranslationUsage": {
+            "type": "object",
+            "properties": {
+                "cache_hits": {
+                    "description": "Texts answered by the translation cache\nexample: 120",
+                    "type": "integer"
+                },
+                "cache_misses": {
+                    "description": "Texts missing from the translation cache\nexample: 14",
+                    "type": "integer"
+                },
+                "characters": {
+                    "description": "Characters sent to the provider\nexample: 18250",
+                    "type": "integer"
+                },
+                "date": {
+                    "description": "Day (UTC)\nexample: 2025-01-15",
+                    "type": "string"
+                },
+                "lang": {
+                    "description": "example: pt-BR",
+                    "type": "string"
+                },
+                "provider": {
+                    "description": "example: deepl",
+                    "type": "string"
+                }
+            }
+        },
+        "i18n.TranslationUsageReport": {
+            "type": "object",
+            "properties": {
+                "budgets": {
+                    "description": "Budgets of the current month",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/handlers.Apod"
+                        "$ref": "#/definitions/i18n.TranslationBudgetStatus"
                     }
                 },
-                "total_pages": {
-                    "description": "Total number of available pages\nexample: 3",
+                "from": {
+                    "description": "First day of the report (UTC)\nexample: 2025-01-01",
+                    "type": "string"
+                },
+                "to": {
+                    "description": "Last day of the report (UTC)\nexample: 2025-01-31",
+                    "type": "string"
+                },
+                "totals": {
+                    "$ref": "#/definitions/i18n.TranslationUsageTotals"
+                },
+                "usage": {
+                    "description": "Usage per day, provider and language",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/i18n.TranslationUsage"
+                    }
+                }
+            }
+        },
+        "i18n.TranslationUsageTotals": {
+            "type": "object",
+            "properties": {
+                "cache_hit_ratio": {
+                    "description": "Share of texts answered by the cache (0 to 1)\nexample: 0.9",
+                    "type": "number"
+                },
+                "cache_hits": {
                     "type": "integer"
                 },
-                "total_results": {
-                    "description": "Total number of results found\nexample: 42",
+                "cache_misses": {
+                    "type": "integer"
+                },
+                "characters": {
                     "type": "integer"
                 }
             }
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"astrovista-api/i18n"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GlossaryResponse lists the glossary entries
+// swagger:model GlossaryResponse
+type GlossaryResponse struct {
+	// Glossary version, changes whenever an entry is added or removed
+	// example: 3f2a9c1b7d4e
+	Version string `json:"version"`
+	// Glossary entries
+	Entries []i18n.GlossaryEntry `json:"entries"`
+}
+
+// ListGlossary returns the translation glossary
+// @Summary List glossary entries
+// @Description Returns the per-language term translations and the do-not-translate terms applied to machine translations
+// @Tags Admin
+// @Produce json
+// @Param Authorization header string false "Bearer token with the admin role"
+// @Param X-API-Key header string false "API key with the admin scope"
+// @Success 200 {object} GlossaryResponse
+// @Failure 500 {object} i18n.ErrorResponse
+// @Router /admin/glossary [get]
+func ListGlossary(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	entries, err := i18n.ListGlossaryEntries(ctx)
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, i18n.CodeGlossaryError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(GlossaryResponse{
+		Version: i18n.NewGlossary(entries).Version(),
+		Entries: entries,
+	})
+}
+
+// CreateGlossaryEntry adds a term to the glossary
+// @Summary Add a glossary entry
+// @Description Adds a term with a fixed translation for one language, or a do-not-translate term when no language is given. Applies to new machine translations.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string false "Bearer token with the admin role"
+// @Param X-API-Key header string false "API key with the admin scope"
+// @Param entry body i18n.GlossaryEntry true "Glossary entry"
+// @Success 201 {object} i18n.GlossaryEntry
+// @Failure 400 {object} i18n.ErrorResponse
+// @Router /admin/glossary [post]
+func CreateGlossaryEntry(w http.ResponseWriter, r *http.Request) {
+	var entry i18n.GlossaryEntry
+	if err := json.NewDecoder(r.Body).Decode(&entry); err != nil {
+		writeError(w, r, http.StatusBadRequest, i18n.CodeInvalidRequestBody, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	created, err := i18n.AddGlossaryEntry(ctx, entry)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, i18n.CodeInvalidGlossaryEntry, err.Error())
+		return
+	}
+	// Stored translations made with the previous glossary are retranslated on their next
+	// read; drop the cached responses that still serve them
+	invalidateCache(ctx)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
+
+// DeleteGlossaryEntry removes a term from the glossary
+// @Summary Delete a glossary entry
+// @Description Removes a term from the glossary
+// @Tags Admin
+// @Produce json
+// @Param Authorization header string false "Bearer token with the admin role"
+// @Param X-API-Key header string false "API key with the admin scope"
+// @Param id path string true "Glossary entry ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} i18n.ErrorResponse
+// @Failure 404 {object} i18n.ErrorResponse
+// @Router /admin/glossary/{id} [delete]
+func DeleteGlossaryEntry(w http.ResponseWriter, r *http.Request) {
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, i18n.CodeInvalidID, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := i18n.DeleteGlossaryEntry(ctx, id); err == i18n.ErrGlossaryEntryNotFound {
+		writeError(w, r, http.StatusNotFound, i18n.CodeGlossaryEntryNotFound, id.Hex())
+		return
+	} else if err != nil {
+		writeError(w, r, http.StatusInternalServerError, i18n.CodeGlossaryError, err.Error())
+		return
+	}
+	// Stored translations made with the previous glossary are retranslated on their next
+	// read; drop the cached responses that still serve them
+	invalidateCache(ctx)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Glossary entry deleted",
+		"id":      id.Hex(),
+	})
+}
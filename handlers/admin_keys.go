@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"astrovista-api/apikeys"
+	"astrovista-api/i18n"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CreateAPIKeyRequest is the request body for issuing a new API key
+// swagger:model CreateAPIKeyRequest
+type CreateAPIKeyRequest struct {
+	// Human readable name of the key owner
+	// example: Mobile app
+	Name string `json:"name"`
+	// Scopes to grant (read, ingest, admin)
+	// example: ["read"]
+	Scopes []string `json:"scopes"`
+	// Maximum number of requests per day (0 = unlimited, omitted = default)
+	// example: 10000
+	DailyQuota *int64 `json:"daily_quota"`
+	// Maximum number of requests per month (0 = unlimited, omitted = default)
+	// example: 200000
+	MonthlyQuota *int64 `json:"monthly_quota"`
+}
+
+// APIKeyIssuedResponse is returned when a key is created or rotated.
+// The plaintext key is only ever shown in this response.
+// swagger:model APIKeyIssuedResponse
+type APIKeyIssuedResponse struct {
+	// Plaintext API key, to be sent in the X-API-Key header
+	// example: av_Xy12abCdEf...
+	Key string `json:"key"`
+	// Stored key metadata
+	APIKey apikeys.APIKey `json:"api_key"`
+}
+
+// APIKeyReport is a key with its current usage
+// swagger:model APIKeyReport
+type APIKeyReport struct {
+	apikeys.APIKey
+	// Current usage counters
+	Usage apikeys.Usage `json:"usage"`
+}
+
+// CreateAPIKey issues a new API key
+// @Summary Create an API key
+// @Description Issues a new API key with scopes and quotas. The plaintext key is only returned once.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string false "Bearer token with the admin role"
+// @Param X-API-Key header string false "API key with the admin scope"
+// @Param key body CreateAPIKeyRequest true "Key settings"
+// @Success 201 {object} APIKeyIssuedResponse
+// @Failure 400 {object} i18n.ErrorResponse
+// @Failure 401 {object} i18n.ErrorResponse
+// @Failure 403 {object} i18n.ErrorResponse
+// @Router /admin/keys [post]
+func CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	var req CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, i18n.CodeInvalidRequestBody, err.Error())
+		return
+	}
+	if req.Name == "" {
+		writeError(w, r, http.StatusBadRequest, i18n.CodeAPIKeyNameRequired, "")
+		return
+	}
+	if len(req.Scopes) == 0 {
+		req.Scopes = []string{apikeys.ScopeRead}
+	}
+
+	dailyQuota := apikeys.DefaultDailyQuota
+	if req.DailyQuota != nil {
+		dailyQuota = *req.DailyQuota
+	}
+	monthlyQuota := apikeys.DefaultMonthlyQuota
+	if req.MonthlyQuota != nil {
+		monthlyQuota = *req.MonthlyQuota
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	key, plaintext, err := apikeys.Create(ctx, req.Name, req.Scopes, dailyQuota, monthlyQuota)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, i18n.CodeAPIKeyError, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(APIKeyIssuedResponse{Key: plaintext, APIKey: *key})
+}
+
+// ListAPIKeys returns every API key with its usage
+// @Summary List API keys
+// @Description Returns every issued API key, including revoked ones, with today's and this month's usage
+// @Tags Admin
+// @Produce json
+// @Param Authorization header string false "Bearer token with the admin role"
+// @Param X-API-Key header string false "API key with the admin scope"
+// @Success 200 {array} APIKeyReport
+// @Failure 401 {object} i18n.ErrorResponse
+// @Failure 403 {object} i18n.ErrorResponse
+// @Failure 500 {object} i18n.ErrorResponse
+// @Router /admin/keys [get]
+func ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	keys, err := apikeys.List(ctx)
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, i18n.CodeAPIKeyError, err.Error())
+		return
+	}
+
+	reports := make([]APIKeyReport, 0, len(keys))
+	for _, key := range keys {
+		usage, err := apikeys.GetUsage(ctx, key.Subject())
+		if err != nil {
+			log.Printf("Error fetching usage for API key %s: %v", key.ID.Hex(), err)
+		}
+		reports = append(reports, APIKeyReport{APIKey: key, Usage: usage})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reports)
+}
+
+// RotateAPIKey replaces the secret of an API key
+// @Summary Rotate an API key
+// @Description Generates a new secret for the key. The old secret stops working immediately on this instance and within a minute on the others.
+// @Tags Admin
+// @Produce json
+// @Param Authorization header string false "Bearer token with the admin role"
+// @Param X-API-Key header string false "API key with the admin scope"
+// @Param id path string true "API key ID"
+// @Success 200 {object} APIKeyIssuedResponse
+// @Failure 400 {object} i18n.ErrorResponse
+// @Failure 404 {object} i18n.ErrorResponse
+// @Router /admin/keys/{id}/rotate [post]
+func RotateAPIKey(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseAPIKeyID(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	key, plaintext, err := apikeys.Rotate(ctx, id)
+	if err != nil {
+		writeAPIKeyError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(APIKeyIssuedResponse{Key: plaintext, APIKey: *key})
+}
+
+// RevokeAPIKey permanently disables an API key
+// @Summary Revoke an API key
+// @Description Permanently disables an API key
+// @Tags Admin
+// @Produce json
+// @Param Authorization header string false "Bearer token with the admin role"
+// @Param X-API-Key header string false "API key with the admin scope"
+// @Param id path string true "API key ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} i18n.ErrorResponse
+// @Failure 404 {object} i18n.ErrorResponse
+// @Router /admin/keys/{id} [delete]
+func RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseAPIKeyID(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := apikeys.Revoke(ctx, id); err != nil {
+		writeAPIKeyError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "API key revoked",
+		"id":      id.Hex(),
+	})
+}
+
+// parseAPIKeyID reads the key ID from the path, writing a 400 response if it is invalid
+func parseAPIKeyID(w http.ResponseWriter, r *http.Request) (primitive.ObjectID, bool) {
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, i18n.CodeInvalidID, err.Error())
+		return primitive.NilObjectID, false
+	}
+	return id, true
+}
+
+// writeAPIKeyError writes a 404 for unknown keys and a 500 otherwise
+func writeAPIKeyError(w http.ResponseWriter, r *http.Request, err error) {
+	if err == apikeys.ErrNotFound {
+		writeError(w, r, http.StatusNotFound, i18n.CodeAPIKeyNotFound, err.Error())
+		return
+	}
+	writeError(w, r, http.StatusInternalServerError, i18n.CodeAPIKeyError, err.Error())
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+// TestCreateAPIKeyValidation checks that invalid requests are rejected before a key is stored
+func TestCreateAPIKeyValidation(t *testing.T) {
+	testCases := []struct {
+		name string
+		body string
+	}{
+		{"Invalid JSON", `{"name":`},
+		{"Missing name", `{"scopes":["read"]}`},
+		{"Unknown scope", `{"name":"Mobile app","scopes":["superuser"]}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/admin/keys", strings.NewReader(tc.body))
+			rr := httptest.NewRecorder()
+			CreateAPIKey(rr, req)
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("Expected status %d, got %d: %s", http.StatusBadRequest, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
+
+// TestAPIKeyInvalidID checks that rotation and revocation reject malformed key IDs
+func TestAPIKeyInvalidID(t *testing.T) {
+	testCases := []struct {
+		name    string
+		method  string
+		path    string
+		handler http.HandlerFunc
+	}{
+		{"Rotate", "POST", "/admin/keys/not-an-id/rotate", RotateAPIKey},
+		{"Revoke", "DELETE", "/admin/keys/not-an-id", RevokeAPIKey},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := mux.SetURLVars(httptest.NewRequest(tc.method, tc.path, nil), map[string]string{"id": "not-an-id"})
+			rr := httptest.NewRecorder()
+			tc.handler(rr, req)
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("Expected status %d, got %d: %s", http.StatusBadRequest, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
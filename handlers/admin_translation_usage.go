@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"astrovista-api/i18n"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// maxUsageReportDays bounds the period of a usage report
+const maxUsageReportDays = 366
+
+// GetTranslationUsage reports the characters sent to the translation providers
+// @Summary Translation usage report
+// @Description Returns the characters sent to each translation provider per day and language, the translation cache hits and misses, and the state of the monthly character budgets
+// @Tags Admin
+// @Produce json
+// @Param Authorization header string false "Bearer token with the admin role"
+// @Param X-API-Key header string false "API key with the admin scope"
+// @Param from query string false "First day (YYYY-MM-DD), defaults to the first day of the month" example("2025-01-01")
+// @Param to query string false "Last day (YYYY-MM-DD), defaults to today" example("2025-01-31")
+// @Success 200 {object} i18n.TranslationUsageReport
+// @Failure 400 {object} i18n.ErrorResponse
+// @Failure 500 {object} i18n.ErrorResponse
+// @Router /admin/translations/usage [get]
+func GetTranslationUsage(w http.ResponseWriter, r *http.Request) {
+	now := time.Now().UTC()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if value := r.URL.Query().Get("from"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, i18n.CodeInvalidDate, err.Error())
+			return
+		}
+		from = parsed
+	}
+	if value := r.URL.Query().Get("to"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, i18n.CodeInvalidDate, err.Error())
+			return
+		}
+		to = parsed
+	}
+	if from.After(to) || to.Sub(from) >= maxUsageReportDays*24*time.Hour {
+		writeError(w, r, http.StatusBadRequest, i18n.CodeDateRangeError, "the period must cover 1 to 366 days")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	report, err := i18n.GetTranslationUsageReport(ctx, from, to)
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, i18n.CodeGeneralError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"astrovista-api/database"
+	"astrovista-api/i18n"
+	"astrovista-api/middleware"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// TranslationFieldReview is the editorial view of one translated APOD field
+// swagger:model TranslationFieldReview
+type TranslationFieldReview struct {
+	// Field name
+	// example: title
+	Field string `json:"field"`
+	// Original text, in the language the APOD is written in
+	// example: Andromeda Galaxy
+	Original string `json:"original"`
+	// Stored translation, if any
+	Translation *i18n.StoredTranslation `json:"translation,omitempty"`
+}
+
+// TranslationDraftRequest is the request body for editing a translation
+// swagger:model TranslationDraftRequest
+type TranslationDraftRequest struct {
+	// Corrected translation
+	// example: Galáxia de Andrômeda
+	Text string `json:"text"`
+}
+
+// GetAPODTranslationReview returns the translations of an APOD for review
+// @Summary View APOD translations
+// @Description Returns the original text of every translatable field of an APOD with its stored machine translation and editorial state
+// @Tags Admin
+// @Produce json
+// @Param Authorization header string false "Bearer token with the admin role"
+// @Param X-API-Key header string false "API key with the admin scope"
+// @Param date path string true "APOD date (YYYY-MM-DD)"
+// @Param lang path string true "Language code"
+// @Success 200 {array} TranslationFieldReview
+// @Failure 400 {object} i18n.ErrorResponse
+// @Failure 404 {object} i18n.ErrorResponse
+// @Failure 500 {object} i18n.ErrorResponse
+// @Router /admin/translations/{date}/{lang} [get]
+func GetAPODTranslationReview(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	date, lang := vars["date"], vars["lang"]
+	if !validTranslationTarget(w, r, lang, "") {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	apod, ok := findTranslatedAPOD(ctx, w, r, date, lang)
+	if !ok {
+		return
+	}
+
+	translations, err := i18n.FindAPODTranslations(ctx, date, lang)
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, i18n.CodeTranslationError, err.Error())
+		return
+	}
+	byField := make(map[string]*i18n.StoredTranslation, len(translations))
+	for i := range translations {
+		byField[translations[i].Field] = &translations[i]
+	}
+
+	original := apodToMap(*apod)
+	reviews := []TranslationFieldReview{}
+	for _, field := range []string{"title", "explanation", "copyright"} {
+		text, _ := original[field].(string)
+		if text == "" && byField[field] == nil {
+			continue
+		}
+		reviews = append(reviews, TranslationFieldReview{
+			Field:       field,
+			Original:    text,
+			Translation: byField[field],
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reviews)
+}
+
+// UpdateAPODTranslation saves an editor's translation of a field as a draft
+// @Summary Edit an APOD translation
+// @Description Saves a corrected translation of an APOD field as a draft. Readers keep getting the current translation until the draft is approved.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string false "Bearer token with the admin role"
+// @Param X-API-Key header string false "API key with the admin scope"
+// @Param date path string true "APOD date (YYYY-MM-DD)"
+// @Param lang path string true "Language code"
+// @Param field path string true "Field (title, explanation or copyright)"
+// @Param translation body TranslationDraftRequest true "Corrected translation"
+// @Success 200 {object} i18n.StoredTranslation
+// @Failure 400 {object} i18n.ErrorResponse
+// @Failure 404 {object} i18n.ErrorResponse
+// @Failure 500 {object} i18n.ErrorResponse
+// @Router /admin/translations/{date}/{lang}/{field} [put]
+func UpdateAPODTranslation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	date, lang, field := vars["date"], vars["lang"], vars["field"]
+	if !validTranslationTarget(w, r, lang, field) {
+		return
+	}
+
+	var req TranslationDraftRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, i18n.CodeInvalidRequestBody, err.Error())
+		return
+	}
+	if req.Text == "" {
+		writeError(w, r, http.StatusBadRequest, i18n.CodeTranslationTextRequired, field)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Drafts are upserted, so an unknown date would create an orphan translation
+	if _, ok := findTranslatedAPOD(ctx, w, r, date, lang); !ok {
+		return
+	}
+
+	translation, err := i18n.SaveTranslationDraft(ctx, date, lang, field, req.Text, editorName(r))
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, i18n.CodeTranslationError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(translation)
+}
+
+// ApproveAPODTranslation makes the draft translation of a field the one served to readers
+// @Summary Approve an APOD translation
+// @Description Approves the pending draft of an APOD field. Approved human translations always take precedence over machine translations.
+// @Tags Admin
+// @Produce json
+// @Param Authorization header string false "Bearer token with the admin role"
+// @Param X-API-Key header string false "API key with the admin scope"
+// @Param date path string true "APOD date (YYYY-MM-DD)"
+// @Param lang path string true "Language code"
+// @Param field path string true "Field (title, explanation or copyright)"
+// @Success 200 {object} i18n.StoredTranslation
+// @Failure 400 {object} i18n.ErrorResponse
+// @Failure 404 {object} i18n.ErrorResponse
+// @Failure 409 {object} i18n.ErrorResponse
+// @Router /admin/translations/{date}/{lang}/{field}/approve [post]
+func ApproveAPODTranslation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	date, lang, field := vars["date"], vars["lang"], vars["field"]
+	if !validTranslationTarget(w, r, lang, field) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, ok := findTranslatedAPOD(ctx, w, r, date, lang); !ok {
+		return
+	}
+
+	translation, err := i18n.ApproveTranslationDraft(ctx, date, lang, field, editorName(r))
+	switch err {
+	case nil:
+	case i18n.ErrTranslationNotFound:
+		writeError(w, r, http.StatusNotFound, i18n.CodeTranslationNotFound, err.Error())
+		return
+	case i18n.ErrNoDraft:
+		writeError(w, r, http.StatusConflict, i18n.CodeNoDraftToApprove, err.Error())
+		return
+	default:
+		writeError(w, r, http.StatusInternalServerError, i18n.CodeTranslationError, err.Error())
+		return
+	}
+	// Cached responses may include the previous translation
+	invalidateCache(ctx)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(translation)
+}
+
+// validTranslationTarget checks the language and field of a translation, writing a 400 response if invalid
+func validTranslationTarget(w http.ResponseWriter, r *http.Request, lang, field string) bool {
+	if !i18n.IsSupportedLanguage(lang) {
+		writeError(w, r, http.StatusBadRequest, i18n.CodeUnsupportedLanguage, lang)
+		return false
+	}
+	if field != "" && !i18n.IsTranslatableAPODField(field) {
+		writeError(w, r, http.StatusBadRequest, i18n.CodeInvalidTranslationField, field)
+		return false
+	}
+	return true
+}
+
+// findTranslatedAPOD loads the APOD a translation belongs to, writing a 404 response when
+// it doesn't exist and a 400 response when lang is the language the APOD is written in
+func findTranslatedAPOD(ctx context.Context, w http.ResponseWriter, r *http.Request, date, lang string) (*Apod, bool) {
+	var apod Apod
+	if err := database.ApodCollection.FindOne(ctx, bson.M{"date": date}).Decode(&apod); err == mongo.ErrNoDocuments {
+		writeError(w, r, http.StatusNotFound, i18n.CodeAPODNotFound, date)
+		return nil, false
+	} else if err != nil {
+		writeError(w, r, http.StatusInternalServerError, i18n.CodeDatabaseError, err.Error())
+		return nil, false
+	}
+	if sourceLang := apod.sourceLanguage(); lang == sourceLang {
+		writeError(w, r, http.StatusBadRequest, i18n.CodeUnsupportedLanguage, fmt.Sprintf("%s is the language the APOD is written in", lang))
+		return nil, false
+	}
+	return &apod, true
+}
+
+// editorName identifies the authenticated editor
+func editorName(r *http.Request) string {
+	if principal := middleware.GetPrincipalFromContext(r.Context()); principal != nil {
+		return principal.Name
+	}
+	return "unknown"
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestValidTranslationTarget checks that English can be reviewed and unknown targets are rejected
+func TestValidTranslationTarget(t *testing.T) {
+	testCases := []struct {
+		lang, field string
+		valid       bool
+	}{
+		{"en", "title", true},
+		{"pt-BR", "explanation", true},
+		{"xx", "title", false},
+		{"pt-BR", "url", false},
+	}
+
+	for _, tc := range testCases {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest("PUT", "/admin/translations/2025-06-10/"+tc.lang+"/"+tc.field, nil)
+		if valid := validTranslationTarget(rr, req, tc.lang, tc.field); valid != tc.valid {
+			t.Errorf("validTranslationTarget(%s, %s) = %v; expected %v", tc.lang, tc.field, valid, tc.valid)
+		}
+		if !tc.valid && rr.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d for %s/%s, got %d", http.StatusBadRequest, tc.lang, tc.field, rr.Code)
+		}
+	}
+}
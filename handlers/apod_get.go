@@ -1,13 +1,11 @@
 package handlers
 
 import (
-	"astrovista-api/cache"
 	"astrovista-api/database"
 	"astrovista-api/i18n"
 	"astrovista-api/middleware"
 	"context"
 	"encoding/json"
-	"log"
 	"net/http"
 	"time"
 
@@ -22,7 +20,7 @@ import (
 // @Accept json
 // @Produce json
 // @Success 200 {object} Apod
-// @Failure 400 {object} map[string]string
+// @Failure 400 {object} i18n.ErrorResponse
 // @Router /apod [get]
 func GetApod(w http.ResponseWriter, r *http.Request) {
 	// Create context with timeout for the database operation
@@ -31,97 +29,37 @@ func GetApod(w http.ResponseWriter, r *http.Request) {
 
 	var apod Apod // struct to store the result
 
-	// Cache key for the most recent APOD
-	cacheKey := "apod:latest"
-
-	// Try to retrieve from cache first
-	found, err := cache.Get(ctx, cacheKey, &apod)
-	if err != nil {
-		// Error accessing cache, just log and continue
-		log.Printf("Error accessing cache: %v", err)
-	}
-	// If found in cache, return immediately
-	if found {
-		w.Header().Set("Content-Type", "application/json")
-		w.Header().Set("X-Cache", "HIT")
-
-		// Get language from request
-		lang := middleware.GetLanguageFromContext(r.Context())
-
-		// If not English, try to translate
-		if lang != "en" {
-			// Convert to map to allow translation
-			apodMap := map[string]interface{}{
-				"_id":             apod.ID,
-				"date":            apod.Date,
-				"explanation":     apod.Explanation,
-				"hdurl":           apod.Hdurl,
-				"media_type":      apod.MediaType,
-				"service_version": apod.ServiceVersion,
-				"title":           apod.Title,
-				"url":             apod.Url,
-			}
-
-			// Translate the necessary fields
-			if err := i18n.TranslateAPOD(apodMap, lang); err != nil {
-				log.Printf("Error translating APOD: %v", err)
-			}
-
-			// Send the translated version
-			json.NewEncoder(w).Encode(apodMap)
-		} else {
-			// No translation, send original
-			json.NewEncoder(w).Encode(apod)
+	// Read the most recent APOD through the cache, concurrent misses share one query
+	status, err := responseLoader.Fetch(ctx, "apod:latest", latestApodPolicy, &apod, func(ctx context.Context) (interface{}, error) {
+		var latest Apod
+		err := database.ApodCollection.FindOne(
+			ctx,
+			bson.M{}, // empty filter = all
+			options.FindOne().SetSort(bson.D{{Key: "date", Value: -1}}), // sort desc
+		).Decode(&latest) // decode the result into the latest variable
+		if err != nil {
+			return nil, &loadError{http.StatusBadRequest, i18n.CodeAPODNotFound, err.Error()}
 		}
-		return
-	}
-	// If not found in cache, search in the database
-	err = database.ApodCollection.FindOne(
-		ctx,
-		bson.M{}, // empty filter = all
-		options.FindOne().SetSort(bson.D{{Key: "date", Value: -1}}), // sort desc
-	).Decode(&apod) // decode the result into the apod variable
-
-	// If found in database, store in cache for future requests
-	if err == nil {
-		// Store in cache for 1 hour (the most recent may change daily)
-		if cacheErr := cache.Set(ctx, cacheKey, apod, 1*time.Hour); cacheErr != nil {
-			log.Printf("Error storing in cache: %v", cacheErr)
-		}
-	}
+		return latest, nil
+	})
 	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(map[string]string{
-			"error": "Document not found",
-		})
+		writeLoadError(w, r, err)
 		return
 	}
-	// If found, return JSON to client
+
 	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("X-Cache", "MISS") // Indicates it came from the database, not cache
+	w.Header().Set("X-Cache", string(status)) // HIT, STALE or MISS (loaded from the database)
 
 	// Get language from request
 	lang := middleware.GetLanguageFromContext(r.Context())
 
-	// If not English, try to translate
-	if lang != "en" {
+	// Translate and localize unless an English APOD is served to an English reader
+	if needsLocalization(lang, apod) {
 		// Convert to map to allow translation
-		apodMap := map[string]interface{}{
-			"_id":             apod.ID,
-			"date":            apod.Date,
-			"explanation":     apod.Explanation,
-			"hdurl":           apod.Hdurl,
-			"media_type":      apod.MediaType,
-			"service_version": apod.ServiceVersion,
-			"title":           apod.Title,
-			"url":             apod.Url,
-		}
+		apodMap := apodToMap(apod)
 
 		// Translate the necessary fields
-		if err := i18n.TranslateAPOD(apodMap, lang); err != nil {
-			log.Printf("Error translating APOD: %v", err)
-		}
+		translateApodMaps(w, r, []map[string]interface{}{apodMap}, lang)
 
 		// Send the translated version
 		json.NewEncoder(w).Encode(apodMap)
@@ -1,12 +1,11 @@
 package handlers
 
 import (
-	"astrovista-api/cache"
 	"astrovista-api/database"
+	"astrovista-api/i18n"
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
 	"os"
 	"time"
@@ -17,27 +16,21 @@ import (
 
 // PostApod fetches the most recent APOD from NASA API and adds it to the database
 // @Summary Adds new APOD from NASA
-// @Description Fetches the most recent APOD from NASA API and adds it to the database
+// @Description Fetches the most recent APOD from NASA API (or the mirror set by APOD_SOURCE_URL) and adds it to the database, detecting the language it is written in
 // @Tags APOD
 // @Accept json
 // @Produce json
-// @Param X-API-Token header string true "Internal API token"
+// @Param Authorization header string false "Bearer token with the ingest role"
+// @Param X-API-Key header string false "API key with the ingest scope"
+// @Param X-API-Token header string false "Internal API token (deprecated)"
 // @Success 201 {object} map[string]interface{}
-// @Failure 401 {object} map[string]string
-// @Failure 409 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Failure 401 {object} i18n.ErrorResponse
+// @Failure 403 {object} i18n.ErrorResponse
+// @Failure 409 {object} i18n.ErrorResponse
+// @Failure 500 {object} i18n.ErrorResponse
 // @Router /apod [post]
 func PostApod(w http.ResponseWriter, r *http.Request) {
-	// Verify basic API token (for internal/scheduled service)
-	apiToken := r.Header.Get("X-API-Token")
-	if apiToken != os.Getenv("INTERNAL_API_TOKEN") {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusUnauthorized)
-		json.NewEncoder(w).Encode(map[string]string{
-			"error": "Unauthorized - Valid API token required",
-		})
-		return
-	}
+	// Authentication is handled by middleware.RequireRole(apikeys.ScopeIngest) on the route
 
 	// Get NASA API key from environment variables
 	nasaAPIKey := os.Getenv("NASA_API_KEY")
@@ -45,45 +38,37 @@ func PostApod(w http.ResponseWriter, r *http.Request) {
 		nasaAPIKey = "DEMO_KEY" // Demo key (limited usage)
 	}
 
-	// NASA APOD API URL
+	// NASA APOD API URL, or a mirror publishing APODs in the same format
 	nasaURL := fmt.Sprintf("https://api.nasa.gov/planetary/apod?api_key=%s", nasaAPIKey)
+	if sourceURL := os.Getenv("APOD_SOURCE_URL"); sourceURL != "" {
+		nasaURL = sourceURL
+	}
 
 	// Make request to NASA API
 	resp, err := http.Get(nasaURL)
 	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"error":   "Error fetching data from NASA API",
-			"details": err.Error(),
-		})
+		writeError(w, r, http.StatusInternalServerError, i18n.CodeNASAAPIError, err.Error())
 		return
 	}
 	defer resp.Body.Close()
 
 	// Check if the response was successful
 	if resp.StatusCode != http.StatusOK {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"error":   "NASA API returned an error",
-			"details": resp.Status,
-		})
+		writeError(w, r, http.StatusInternalServerError, i18n.CodeNASAAPIError, resp.Status)
 		return
 	}
 
 	// Decode JSON response
 	var apod Apod
 	if err := json.NewDecoder(resp.Body).Decode(&apod); err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"error":   "Error decoding NASA API response",
-			"details": err.Error(),
-		})
+		writeError(w, r, http.StatusInternalServerError, i18n.CodeNASAAPIError, err.Error())
 		return
 	}
 
+	// Mirrors may publish in other languages: keep the declared source_lang when it is
+	// supported, detect it from the texts otherwise
+	apod.SourceLang = i18n.SourceLanguage(apod.SourceLang, apod.Title, apod.Explanation)
+
 	// Create context with timeout for MongoDB operations
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -94,46 +79,26 @@ func PostApod(w http.ResponseWriter, r *http.Request) {
 
 	// If there was no error, it means a document with this date already exists
 	if existingApod.Err() == nil {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusConflict) // 409 Conflict
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"error":   "APOD already exists for this date",
-			"details": apod.Date,
-		})
+		writeError(w, r, http.StatusConflict, i18n.CodeAPODAlreadyExists, apod.Date)
 		return
 	} else if existingApod.Err() != mongo.ErrNoDocuments {
 		// If the error is different from ErrNoDocuments, there was a database problem
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"error":   "Error checking for existing APOD",
-			"details": existingApod.Err().Error(),
-		})
+		writeError(w, r, http.StatusInternalServerError, i18n.CodeDatabaseError, existingApod.Err().Error())
 		return
 	}
 
 	// Insert the new document
 	result, err := database.ApodCollection.InsertOne(ctx, apod)
 	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"error":   "Error inserting APOD into database",
-			"details": err.Error(),
-		})
+		writeError(w, r, http.StatusInternalServerError, i18n.CodeDatabaseError, err.Error())
 		return
 	}
-	// Invalidate related cache
-	// 1. Remove the most recent APOD from cache
-	if err := cache.Delete(ctx, "apod:latest"); err != nil {
-		log.Printf("Error invalidating cache for the most recent APOD: %v", err)
-	}
+	// Invalidate the cached responses that may include the new APOD: the most recent
+	// APOD, its date, and every date range and search
+	invalidateCache(ctx, tagLatest, apodTag(apod.Date), tagRange, tagSearch)
 
-	// 2. Remove any specific cache for this date
-	cacheKey := "apod:date:" + apod.Date
-	if err := cache.Delete(ctx, cacheKey); err != nil {
-		log.Printf("Error invalidating cache for date %s: %v", apod.Date, err)
-	}
+	// Translate the new APOD into every supported language in the background
+	i18n.EnqueuePrecompute(apodToMap(apod))
 
 	// Return success with the inserted ID
 	w.Header().Set("Content-Type", "application/json")
@@ -7,7 +7,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
 	"time"
 
@@ -21,8 +20,8 @@ import (
 // @Accept json
 // @Produce json
 // @Success 200 {object} AllApodsResponse
-// @Failure 400 {object} map[string]interface{}
-// @Failure 404 {object} map[string]interface{}
+// @Failure 400 {object} i18n.ErrorResponse
+// @Failure 404 {object} i18n.ErrorResponse
 // @Router /apods [get]
 func GetAllApods(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -31,35 +30,20 @@ func GetAllApods(w http.ResponseWriter, r *http.Request) {
 	cursor, err := database.ApodCollection.Find(ctx, bson.M{})
 	if err != nil {
 		fmt.Printf("MongoDB error: %v\n", err)
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"error":   "Error fetching documents",
-			"details": err.Error(),
-		})
+		writeError(w, r, http.StatusBadRequest, i18n.CodeDatabaseError, err.Error())
 		return
 	}
 	defer cursor.Close(ctx)
 
 	var apods []Apod
 	if err = cursor.All(ctx, &apods); err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"error":   "Error decoding documents",
-			"details": err.Error(),
-		})
+		writeError(w, r, http.StatusBadRequest, i18n.CodeDatabaseError, err.Error())
 		return
 	}
 
 	// Check if no documents were found
 	if len(apods) == 0 {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusNotFound)
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"error":   "No documents found!",
-			"details": "No APODs found in the database.",
-		})
+		writeError(w, r, http.StatusNotFound, i18n.CodeNoDocumentsFound, "No APODs found in the database.")
 		return
 	}
 
@@ -75,39 +59,19 @@ func GetAllApods(w http.ResponseWriter, r *http.Request) {
 	// Get language from the request
 	lang := middleware.GetLanguageFromContext(r.Context())
 
-	// If not English, try to translate each APOD in the result
-	if lang != "en" {
+	// Translate and localize each APOD unless English APODs are served to an English reader
+	if needsLocalization(lang, response.Apods...) {
 		// Create a translated response
 		var translatedResponse AllApodsResponse
 		translatedResponse.Count = response.Count
-		translatedApods := make([]map[string]interface{}, 0, len(response.Apods))
-
-		// Translate each APOD
-		for _, apod := range response.Apods {
-			// Convert to map to allow translation
-			apodMap := map[string]interface{}{
-				"_id":             apod.ID,
-				"date":            apod.Date,
-				"explanation":     apod.Explanation,
-				"hdurl":           apod.Hdurl,
-				"media_type":      apod.MediaType,
-				"service_version": apod.ServiceVersion,
-				"title":           apod.Title,
-				"url":             apod.Url,
-			}
-
-			// Translate the necessary fields
-			if err := i18n.TranslateAPOD(apodMap, lang); err != nil {
-				log.Printf("Error translating APOD: %v", err)
-			}
-
-			translatedApods = append(translatedApods, apodMap)
-		}
+		// Translate the whole page in a single batch
+		translatedApods := translateApods(w, r, response.Apods, lang)
 
 		// Create a custom response
 		customResponse := map[string]interface{}{
-			"count": translatedResponse.Count,
-			"apods": translatedApods,
+			"count":         translatedResponse.Count,
+			"count_display": i18n.FormatNumber(translatedResponse.Count, lang),
+			"apods":         translatedApods,
 		}
 
 		// Send the translated version
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"astrovista-api/cache"
+	"astrovista-api/middleware"
+	"context"
+	"log"
+	"time"
+)
+
+// responseLoader reads APODs and responses through the cache, coalescing concurrent loads
+// of the same key. It uses an in-memory cache until SetCache is called at startup.
+var responseLoader = cache.NewLoader(cache.NewTaggedCache(cache.NewMemoryCache(1000), cache.NewMemoryTagIndex()), nil)
+
+// Cache tags of the responses, invalidated by the writes that change them
+const (
+	// The most recent APOD
+	tagLatest = "latest"
+	// Date ranges, which may include any APOD
+	tagRange = "range"
+	// Searches, which may include any APOD
+	tagSearch = "search"
+)
+
+// apodTag is the cache tag of the responses of an APOD
+func apodTag(date string) string {
+	return "apod:" + date
+}
+
+// Cache policies of the responses: how long they are fresh, then served stale while
+// they are refreshed
+var (
+	// The most recent APOD changes daily
+	latestApodPolicy = cache.Policy{TTL: time.Hour, Stale: 10 * time.Minute, Tags: []string{tagLatest}}
+	// Historical APODs never change
+	apodDatePolicy = cache.Policy{TTL: 30 * 24 * time.Hour, Stale: 24 * time.Hour}
+	// Specific date ranges rarely change
+	dateRangePolicy = cache.Policy{TTL: 12 * time.Hour, Stale: time.Hour, Tags: []string{tagRange}}
+	searchPolicy    = cache.Policy{TTL: 5 * time.Minute, Stale: time.Minute, Tags: []string{tagSearch}}
+)
+
+// SetCache sets the cache the handlers store APODs and responses in. locker may be nil;
+// when set, replicas also agree on which one loads a missing or stale response. It must
+// be called before the server starts handling requests.
+func SetCache(c cache.Cache, locker cache.Locker) {
+	responseLoader = cache.NewLoader(c, locker)
+}
+
+// invalidateCache deletes the cached responses recorded under the tags, and every
+// pre-compressed response since their bodies may include what changed
+func invalidateCache(ctx context.Context, tags ...string) {
+	tags = append(tags, middleware.CompressedCacheTag)
+	if err := cache.InvalidateTags(ctx, responseLoader.Cache(), tags...); err != nil {
+		log.Printf("Error invalidating cache tags %v: %v", tags, err)
+	}
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"astrovista-api/i18n"
+	"astrovista-api/middleware"
+	"errors"
+	"net/http"
+)
+
+// writeError writes an error response localized to the language of the request
+func writeError(w http.ResponseWriter, r *http.Request, status int, code i18n.ErrorCode, details string) {
+	i18n.WriteError(w, middleware.GetLanguageFromContext(r.Context()), status, code, details)
+}
+
+// loadError is a failure to load a cached response, with the error response it produces
+type loadError struct {
+	status  int
+	code    i18n.ErrorCode
+	details string
+}
+
+func (e *loadError) Error() string {
+	return string(e.code) + ": " + e.details
+}
+
+// writeLoadError writes the error response of a failed load
+func writeLoadError(w http.ResponseWriter, r *http.Request, err error) {
+	var failure *loadError
+	if !errors.As(err, &failure) {
+		failure = &loadError{http.StatusInternalServerError, i18n.CodeDatabaseError, err.Error()}
+	}
+	writeError(w, r, failure.status, failure.code, failure.details)
+}
@@ -37,6 +37,18 @@ type Apod struct {
 	// example: https://apod.nasa.gov/apod/image/2301/M31_HubbleSpitzerGendler_960.jpg
 	// format: uri
 	Url string `bson:"url" json:"url"`
+	// Language the APOD is written in, detected when it is added (English when missing)
+	// example: en
+	SourceLang string `bson:"source_lang,omitempty" json:"source_lang"`
+}
+
+// sourceLanguage returns the language the APOD is written in, English for APODs stored
+// before languages were detected
+func (a Apod) sourceLanguage() string {
+	if a.SourceLang == "" {
+		return "en"
+	}
+	return a.SourceLang
 }
 
 // AllApodsResponse is the response structure for endpoints that return multiple APODs
@@ -90,6 +102,7 @@ func (a Apod) MarshalJSON() ([]byte, error) {
 		"service_version": a.ServiceVersion,
 		"title":           a.Title,
 		"url":             a.Url,
+		"source_lang":     a.sourceLanguage(),
 	}
 
 	// In standard serialization we don't do anything
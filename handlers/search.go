@@ -10,7 +10,6 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"log"
 	"math"
 	"net/http"
 	"strconv"
@@ -37,79 +36,10 @@ import (
 // @Param endDate query string false "End date (YYYY-MM-DD format)" example(2023-01-31)
 // @Param sort query string false "Sort order (asc or desc)" example(desc) Enums(asc, desc)
 // @Success 200 {object} SearchResponse
-// @Failure 400 {object} map[string]interface{}
-// @Failure 404 {object} map[string]interface{}
+// @Failure 400 {object} i18n.ErrorResponse
+// @Failure 404 {object} i18n.ErrorResponse
 // @Router /apods/search [get]
 func SearchApods(w http.ResponseWriter, r *http.Request) {
-	// Create a cache key from the complete query string
-	queryHash := md5.Sum([]byte(r.URL.RawQuery))
-	cacheKey := "search:" + hex.EncodeToString(queryHash[:])
-
-	// Try to retrieve results from cache
-	var cachedResponse SearchResponse
-	found, err := cache.Get(r.Context(), cacheKey, &cachedResponse)
-	if err != nil {
-		log.Printf("Error accessing cache for search: %v", err)
-	}
-	// If found in cache, return immediately
-	if found {
-		w.Header().Set("Content-Type", "application/json")
-		w.Header().Set("X-Cache", "HIT")
-
-		// Get language from request
-		lang := middleware.GetLanguageFromContext(r.Context())
-
-		// If not English, try to translate each APOD in the result
-		if lang != "en" {
-			// Create a translated response
-			translatedResponse := SearchResponse{
-				TotalResults: cachedResponse.TotalResults,
-				Page:         cachedResponse.Page,
-				PerPage:      cachedResponse.PerPage,
-				TotalPages:   cachedResponse.TotalPages,
-			}
-
-			translatedApods := make([]map[string]interface{}, 0, len(cachedResponse.Results))
-
-			// Translate each APOD
-			for _, apod := range cachedResponse.Results {
-				// Convert to map to allow translation
-				apodMap := map[string]interface{}{
-					"_id":             apod.ID,
-					"date":            apod.Date,
-					"explanation":     apod.Explanation,
-					"hdurl":           apod.Hdurl,
-					"media_type":      apod.MediaType,
-					"service_version": apod.ServiceVersion,
-					"title":           apod.Title,
-					"url":             apod.Url,
-				}
-
-				// Translate the necessary fields
-				if err := i18n.TranslateAPOD(apodMap, lang); err != nil {
-					log.Printf("Error translating APOD: %v", err)
-				}
-
-				translatedApods = append(translatedApods, apodMap)
-			} // Create a custom response with standardized fields
-			customResponse := map[string]interface{}{
-				"total_results": translatedResponse.TotalResults,
-				"page":          translatedResponse.Page,
-				"per_page":      translatedResponse.PerPage,
-				"total_pages":   translatedResponse.TotalPages,
-				"results":       translatedApods,
-			}
-
-			// Send the translated version
-			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(customResponse)
-		} else {
-			// No translation, send original
-			json.NewEncoder(w).Encode(cachedResponse)
-		}
-		return
-	}
-
 	// Get parameters from query string
 	query := r.URL.Query()
 	// Pagination (defaults: page 1, 20 items per page)
@@ -221,42 +151,79 @@ func SearchApods(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 	defer cancel()
 
+	// Read the results through the cache under a key made from the complete query string,
+	// concurrent misses share one query
+	queryHash := md5.Sum([]byte(r.URL.RawQuery))
+	cacheKey := "search:" + hex.EncodeToString(queryHash[:])
+	var response SearchResponse
+	status, err := responseLoader.Fetch(ctx, cacheKey, searchPolicy, &response, func(ctx context.Context) (interface{}, error) {
+		return loadSearchResults(ctx, filter, findOptions, page, perPage)
+	})
+	if err != nil {
+		writeLoadError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Cache", string(status))
+
+	// Get language from request
+	lang := middleware.GetLanguageFromContext(r.Context())
+
+	// Translate and localize each APOD unless English APODs are served to an English reader
+	if !needsLocalization(lang, response.Results...) {
+		// No translation, send original
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// Translate the whole page in a single batch
+	translatedApods := translateApods(w, r, response.Results, lang)
+
+	// Create a custom response with standardized fields
+	customResponse := map[string]interface{}{
+		"total_results":         response.TotalResults,
+		"total_results_display": i18n.FormatNumber(response.TotalResults, lang),
+		"page":                  response.Page,
+		"per_page":              response.PerPage,
+		"total_pages":           response.TotalPages,
+		"results":               translatedApods,
+	}
+	if status == cache.StatusMiss {
+		customResponse = map[string]interface{}{
+			"totalResults":        response.TotalResults,
+			"totalResultsDisplay": i18n.FormatNumber(response.TotalResults, lang),
+			"page":                response.Page,
+			"perPage":             response.PerPage,
+			"totalPages":          response.TotalPages,
+			"results":             translatedApods,
+		}
+	}
+
+	// Send the translated version
+	json.NewEncoder(w).Encode(customResponse)
+}
+
+// loadSearchResults runs a search on the database
+func loadSearchResults(ctx context.Context, filter bson.M, findOptions *options.FindOptions, page, perPage int) (SearchResponse, error) {
 	// First, count the total number of documents to calculate pagination
 	totalResults, err := database.ApodCollection.CountDocuments(ctx, filter)
 	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"error":   "Error counting documents",
-			"details": err.Error(),
-		})
-		return
+		return SearchResponse{}, &loadError{http.StatusInternalServerError, i18n.CodeDatabaseError, err.Error()}
 	}
 
 	// Next, fetch the documents of the current page
 	cursor, err := database.ApodCollection.Find(ctx, filter, findOptions)
 	if err != nil {
 		fmt.Printf("MongoDB search error: %v\n", err)
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"error":   "Error searching documents",
-			"details": err.Error(),
-		})
-		return
+		return SearchResponse{}, &loadError{http.StatusInternalServerError, i18n.CodeDatabaseError, err.Error()}
 	}
 	defer cursor.Close(ctx)
 
 	// Decodes the results
 	var apods []Apod
 	if err = cursor.All(ctx, &apods); err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"error":   "Error decoding search results",
-			"details": err.Error(),
-		})
-		return
+		return SearchResponse{}, &loadError{http.StatusInternalServerError, i18n.CodeDatabaseError, err.Error()}
 	}
 	// Checks if any results were found
 	if len(apods) == 0 && page == 1 {
@@ -264,74 +231,17 @@ func SearchApods(w http.ResponseWriter, r *http.Request) {
 		filterJSON, _ := json.Marshal(filter)
 		fmt.Printf("No results found for filter: %s\n", string(filterJSON))
 
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusNotFound)
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"error": "No documents found matching the search criteria",
-		})
-		return
+		return SearchResponse{}, &loadError{http.StatusNotFound, i18n.CodeSearchNoResults, ""}
 	}
 
 	// Calculates the total number of pages
 	totalPages := int(math.Ceil(float64(totalResults) / float64(perPage)))
 
-	// Prepares the response
-	response := SearchResponse{
+	return SearchResponse{
 		TotalResults: int(totalResults),
 		Page:         page,
 		PerPage:      perPage,
 		TotalPages:   totalPages,
 		Results:      apods,
-	} // Stores the response in the cache with an expiration of 5 minutes
-	if err := cache.Set(r.Context(), cacheKey, response, 5*time.Minute); err != nil {
-		log.Printf("Error storing in cache: %v", err)
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("X-Cache", "MISS")
-
-	// Get language from request
-	lang := middleware.GetLanguageFromContext(r.Context())
-
-	// If not English, try to translate each APOD in the result
-	if lang != "en" {
-		translatedApods := make([]map[string]interface{}, 0, len(response.Results))
-
-		// Translate each APOD
-		for _, apod := range response.Results {
-			// Convert to map to allow translation
-			apodMap := map[string]interface{}{
-				"_id":             apod.ID,
-				"date":            apod.Date,
-				"explanation":     apod.Explanation,
-				"hdurl":           apod.Hdurl,
-				"media_type":      apod.MediaType,
-				"service_version": apod.ServiceVersion,
-				"title":           apod.Title,
-				"url":             apod.Url,
-			}
-
-			// Translate the necessary fields
-			if err := i18n.TranslateAPOD(apodMap, lang); err != nil {
-				log.Printf("Error translating APOD: %v", err)
-			}
-
-			translatedApods = append(translatedApods, apodMap)
-		}
-
-		// Create a custom response
-		customResponse := map[string]interface{}{
-			"totalResults": response.TotalResults,
-			"page":         response.Page,
-			"perPage":      response.PerPage,
-			"totalPages":   response.TotalPages,
-			"results":      translatedApods,
-		}
-
-		// Send the translated version
-		json.NewEncoder(w).Encode(customResponse)
-	} else {
-		// No translation, send original
-		json.NewEncoder(w).Encode(response)
-	}
+	}, nil
 }
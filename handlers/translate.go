@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"astrovista-api/i18n"
+	"context"
+	"log"
+	"net/http"
+)
+
+// TranslationPartialHeader is set when some fields could not be translated in time
+// and are returned in English
+const TranslationPartialHeader = "X-Translation-Partial"
+
+// apodToMap converts an APOD to a map so its fields can be translated
+func apodToMap(apod Apod) map[string]interface{} {
+	return map[string]interface{}{
+		"_id":             apod.ID,
+		"date":            apod.Date,
+		"explanation":     apod.Explanation,
+		"hdurl":           apod.Hdurl,
+		"media_type":      apod.MediaType,
+		"service_version": apod.ServiceVersion,
+		"title":           apod.Title,
+		"url":             apod.Url,
+		"source_lang":     apod.sourceLanguage(),
+	}
+}
+
+// needsLocalization reports whether the APODs must be translated or given localized
+// presentation fields: always for languages other than English, and for English readers
+// when any of the APODs is written in another language
+func needsLocalization(lang string, apods ...Apod) bool {
+	if lang != "en" {
+		return true
+	}
+	for _, apod := range apods {
+		if apod.sourceLanguage() != lang {
+			return true
+		}
+	}
+	return false
+}
+
+// translateApods converts a page of APODs to maps and translates them in a single batch
+func translateApods(w http.ResponseWriter, r *http.Request, apods []Apod, lang string) []map[string]interface{} {
+	translatedApods := make([]map[string]interface{}, 0, len(apods))
+	for _, apod := range apods {
+		translatedApods = append(translatedApods, apodToMap(apod))
+	}
+
+	translateApodMaps(w, r, translatedApods, lang)
+	return translatedApods
+}
+
+// translateApodMaps translates APOD maps within the translation deadline and adds their
+// presentation fields. Fields that could not be translated stay in the original language
+// and the response is flagged as partial.
+func translateApodMaps(w http.ResponseWriter, r *http.Request, apodMaps []map[string]interface{}, lang string) {
+	ctx, cancel := context.WithTimeout(r.Context(), i18n.TranslationTimeout())
+	defer cancel()
+
+	if err := i18n.TranslateAPODs(ctx, apodMaps, lang); err != nil {
+		log.Printf("Error translating APODs: %v", err)
+		w.Header().Set(TranslationPartialHeader, "true")
+	}
+	localizeApodMaps(apodMaps, lang)
+}
+
+// localizeApodMaps adds the presentation fields of the response language to APOD maps:
+// the date formatted for the language and the text direction
+func localizeApodMaps(apodMaps []map[string]interface{}, lang string) {
+	for _, apodMap := range apodMaps {
+		if date, ok := apodMap["date"].(string); ok {
+			if display, err := i18n.FormatDate(date, lang); err == nil {
+				apodMap["date_display"] = display
+			}
+		}
+		apodMap["dir"] = i18n.TextDirection(lang)
+	}
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"astrovista-api/i18n"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// TranslationStatusResponse reports the health of the translation providers
+// swagger:model TranslationStatusResponse
+type TranslationStatusResponse struct {
+	// Overall state: "ok" when the first provider is available, "degraded" when a
+	// fallback is in use and "unavailable" when every circuit is open
+	// example: ok
+	Status string `json:"status"`
+	// Providers in fallback order
+	Providers []TranslationProviderHealth `json:"providers"`
+}
+
+// TranslationProviderHealth is the public view of a provider's circuit breaker: its state
+// and timestamps, without the error details and counters reserved to administrators
+// swagger:model TranslationProviderHealth
+type TranslationProviderHealth struct {
+	// Provider name
+	// example: deepl
+	Name string `json:"name"`
+	// Circuit breaker state: closed, open or half-open
+	// example: closed
+	State       string     `json:"state"`
+	LastSuccess *time.Time `json:"last_success,omitempty"`
+	LastFailure *time.Time `json:"last_failure,omitempty"`
+	RetryAt     *time.Time `json:"retry_at,omitempty"`
+}
+
+// AdminTranslationStatusResponse reports the health of the translation providers with
+// their error details, counters and cache statistics
+// swagger:model AdminTranslationStatusResponse
+type AdminTranslationStatusResponse struct {
+	// Overall state, as in TranslationStatusResponse
+	// example: ok
+	Status string `json:"status"`
+	// Providers in fallback order
+	Providers []i18n.ProviderStatus `json:"providers"`
+}
+
+// GetTranslationStatus returns the state of the translation providers
+// @Summary Translation provider status
+// @Description Returns the translation providers in fallback order with their circuit breaker state and the time of their last success, last failure and next retry
+// @Tags Configuration
+// @Produce json
+// @Success 200 {object} TranslationStatusResponse
+// @Router /translation/status [get]
+func GetTranslationStatus(w http.ResponseWriter, r *http.Request) {
+	providers := i18n.TranslationStatus()
+
+	health := make([]TranslationProviderHealth, len(providers))
+	for i, provider := range providers {
+		health[i] = TranslationProviderHealth{
+			Name:        provider.Name,
+			State:       provider.State,
+			LastSuccess: provider.LastSuccess,
+			LastFailure: provider.LastFailure,
+			RetryAt:     provider.RetryAt,
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(TranslationStatusResponse{
+		Status:    translationStatus(providers),
+		Providers: health,
+	})
+}
+
+// GetAdminTranslationStatus returns the state of the translation providers with their details
+// @Summary Detailed translation provider status
+// @Description Returns the translation providers in fallback order with their circuit breaker state, health counters, last error and cache statistics
+// @Tags Admin
+// @Produce json
+// @Param Authorization header string false "Bearer token with the admin role"
+// @Param X-API-Key header string false "API key with the admin scope"
+// @Success 200 {object} AdminTranslationStatusResponse
+// @Router /admin/translations/status [get]
+func GetAdminTranslationStatus(w http.ResponseWriter, r *http.Request) {
+	providers := i18n.TranslationStatus()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(AdminTranslationStatusResponse{
+		Status:    translationStatus(providers),
+		Providers: providers,
+	})
+}
+
+// translationStatus sums up the state of the providers
+func translationStatus(providers []i18n.ProviderStatus) string {
+	for i, provider := range providers {
+		if provider.State != i18n.CircuitOpen {
+			if i == 0 {
+				return "ok"
+			}
+			return "degraded"
+		}
+	}
+	return "unavailable"
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestGetTranslationStatusHidesDetails checks that the public status only exposes the
+// breaker state and timestamps of the providers
+func TestGetTranslationStatusHidesDetails(t *testing.T) {
+	rr := httptest.NewRecorder()
+	GetTranslationStatus(rr, httptest.NewRequest("GET", "/translation/status", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rr.Code)
+	}
+
+	var response struct {
+		Status    string                   `json:"status"`
+		Providers []map[string]interface{} `json:"providers"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Invalid response: %v", err)
+	}
+	if len(response.Providers) == 0 {
+		t.Fatal("Expected at least one provider")
+	}
+	allowed := map[string]bool{"name": true, "state": true, "last_success": true, "last_failure": true, "retry_at": true}
+	for _, provider := range response.Providers {
+		for field := range provider {
+			if !allowed[field] {
+				t.Errorf("Unexpected public field %q in %+v", field, provider)
+			}
+		}
+	}
+}
@@ -0,0 +1,291 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// batchLimits describes how much text a provider accepts in a single request
+type batchLimits struct {
+	maxItems int // Maximum number of texts per request
+	maxChars int // Maximum number of characters per request
+}
+
+// chunkTranslator translates one chunk of texts, all in the same format, in a single provider call
+type chunkTranslator func(ctx context.Context, chunk []string, format textFormat) ([]string, error)
+
+// Concurrency and deadline settings, read from the environment by InitTranslationService
+var (
+	// translationWorkers bounds how many provider calls run at the same time
+	translationWorkers = 4
+	// translationTimeout is how long a request waits for translations before answering
+	translationTimeout = 5 * time.Second
+)
+
+// lateTranslationTimeout bounds the provider calls that go on after the request that made
+// them stopped waiting
+const lateTranslationTimeout = 30 * time.Second
+
+// TranslationTimeout returns how long handlers should wait for translations
+func TranslationTimeout() time.Duration {
+	return translationTimeout
+}
+
+// loadConcurrencySettings reads TRANSLATION_WORKERS and TRANSLATION_TIMEOUT
+func loadConcurrencySettings() {
+	if value := os.Getenv("TRANSLATION_WORKERS"); value != "" {
+		if workers, err := strconv.Atoi(value); err == nil && workers > 0 {
+			translationWorkers = workers
+		} else {
+			log.Printf("Invalid value for TRANSLATION_WORKERS ignored: %s (using %d as default)", value, translationWorkers)
+		}
+	}
+	if value := os.Getenv("TRANSLATION_TIMEOUT"); value != "" {
+		if timeout, err := time.ParseDuration(value); err == nil && timeout > 0 {
+			translationTimeout = timeout
+		} else {
+			log.Printf("Invalid value for TRANSLATION_TIMEOUT ignored: %s (using %s as default)", value, translationTimeout)
+		}
+	}
+}
+
+// batchTranslate resolves the texts from the cache, translates the remaining ones in as few
+// chunked calls as the provider limits allow, and stores the new translations in the cache.
+// Identical texts are only sent once and chunks are translated concurrently. URLs and
+// catalogue numbers are replaced by placeholders the provider can't alter, and texts with
+// inline markup are sent in HTML mode so links survive the translation. The characters
+// sent and the cache hits and misses are counted against the provider's usage, and nothing
+// is sent when it would exceed a monthly budget; the characters are reserved before the
+// call so concurrent requests can't overshoot it.
+// Every chunk handed to the provider is counted, whatever its outcome, since the provider
+// bills it anyway. When the context is done first, the calls in flight go on in the
+// background and their translations are cached for the next request.
+// On error, the texts that could not be translated are returned unchanged alongside the error.
+func batchTranslate(
+	ctx context.Context,
+	cache *TranslationCache,
+	provider string,
+	texts []string,
+	sourceLang, targetLang string,
+	limits batchLimits,
+	translateChunk chunkTranslator,
+) ([]string, error) {
+	results := make([]string, len(texts))
+	copy(results, texts)
+	glossaryVersion := cacheGlossaryVersion(sourceLang, targetLang)
+
+	// Collect the distinct texts missing from the cache
+	var pending []string
+	var hits, misses, pendingChars int64
+	positions := make(map[string][]int)
+	for i, text := range texts {
+		if text == "" {
+			continue
+		}
+		if cachedText, found := cache.Get(translationCacheKey(provider, sourceLang, targetLang, glossaryVersion, text)); found {
+			results[i] = cachedText
+			hits++
+			continue
+		}
+		misses++
+		if _, seen := positions[text]; !seen {
+			pending = append(pending, text)
+			pendingChars += int64(len([]rune(text)))
+		}
+		positions[text] = append(positions[text], i)
+	}
+
+	settleBudget, err := reserveTranslationBudget(ctx, provider, targetLang, pendingChars)
+	if err != nil {
+		recordTranslationUsage(provider, targetLang, 0, hits, misses)
+		return results, err
+	}
+
+	// Protect URLs and catalogue numbers, and keep texts with markup apart so they are
+	// sent in HTML mode
+	protected := make([]string, len(pending))
+	originals := make([][]string, len(pending))
+	pendingByFormat := make(map[textFormat][]int)
+	for i, text := range pending {
+		protected[i], originals[i] = protectText(text)
+		format := detectFormat(text)
+		pendingByFormat[format] = append(pendingByFormat[format], i)
+	}
+	var chunks [][]string
+	var chunkPending [][]int // Positions in pending of the texts of each chunk
+	var formats []textFormat
+	for _, format := range []textFormat{formatText, formatHTML} {
+		formatPending := pendingByFormat[format]
+		formatTexts := make([]string, len(formatPending))
+		for i, position := range formatPending {
+			formatTexts[i] = protected[position]
+		}
+		for _, chunk := range chunkTexts(formatTexts, limits) {
+			chunks = append(chunks, chunk)
+			chunkPending = append(chunkPending, formatPending[:len(chunk)])
+			formats = append(formats, format)
+			formatPending = formatPending[len(chunk):]
+		}
+	}
+
+	// cacheChunk caches the translations of a chunk and returns them restored
+	cacheChunk := func(index int, translated []string) []string {
+		restored := make([]string, len(translated))
+		for i, position := range chunkPending[index] {
+			restored[i] = restoreText(translated[i], originals[position])
+			cache.Set(translationCacheKey(provider, sourceLang, targetLang, glossaryVersion, pending[position]), restored[i])
+		}
+		return restored
+	}
+	dispatched, err := translateConcurrently(ctx, chunks, func(ctx context.Context, index int, chunk []string) ([]string, error) {
+		return translateChunk(ctx, chunk, formats[index])
+	}, func(index int, translated []string) {
+		for i, text := range cacheChunk(index, translated) {
+			for _, resultPosition := range positions[pending[chunkPending[index][i]]] {
+				results[resultPosition] = text
+			}
+		}
+	}, func(index int, translated []string) {
+		cacheChunk(index, translated)
+	})
+
+	// Chunks are dispatched in order
+	var sentChars int64
+	for _, chunkPositions := range chunkPending[:dispatched] {
+		for _, position := range chunkPositions {
+			sentChars += int64(len([]rune(pending[position])))
+		}
+	}
+	settleBudget(sentChars)
+	recordTranslationUsage(provider, targetLang, sentChars, hits, misses)
+
+	return results, err
+}
+
+// translationCacheKey builds the translation cache key of a text. The text is identified
+// by its SHA-256 hash, so distinct texts never share a key, and the glossary version keeps
+// translations made before a glossary change from being served after it.
+func translationCacheKey(provider, sourceLang, targetLang, glossaryVersion, text string) string {
+	return fmt.Sprintf("%s:%s:%s:%s:%s", provider, sourceLang, targetLang, glossaryVersion, sourceHash(text))
+}
+
+// cacheGlossaryVersion returns the glossary version that applies to the translation,
+// or an empty string when no glossary applies
+func cacheGlossaryVersion(sourceLang, targetLang string) string {
+	glossary := CurrentGlossary()
+	if !glossary.Applies(sourceLang, targetLang) {
+		return ""
+	}
+	return glossary.Version()
+}
+
+// chunkResult is the outcome of translating one chunk
+type chunkResult struct {
+	index      int
+	translated []string
+	err        error
+}
+
+// translateConcurrently translates the chunks with at most translationWorkers calls in
+// flight and returns how many chunks were handed to translateChunk, always the first ones.
+// onResult is called from the calling goroutine for every successful chunk.
+// When the context is done, it returns immediately with the context error and dispatches
+// no more chunks. Without onLateResult, the chunks still in flight are cancelled and their
+// results discarded. With onLateResult, the calls run under a context that outlives the
+// caller's (bounded by lateTranslationTimeout), so the chunks still in flight complete in
+// the background and onLateResult is called for each of them that succeeds.
+func translateConcurrently(ctx context.Context, chunks [][]string, translateChunk func(ctx context.Context, index int, chunk []string) ([]string, error), onResult, onLateResult func(index int, translated []string)) (int, error) {
+	if len(chunks) == 0 {
+		return 0, nil
+	}
+
+	callCtx, cancelCalls := ctx, context.CancelFunc(func() {})
+	if onLateResult != nil {
+		callCtx, cancelCalls = context.WithTimeout(context.WithoutCancel(ctx), lateTranslationTimeout)
+	}
+
+	// Buffered so that abandoned workers never block
+	resultsCh := make(chan chunkResult, len(chunks))
+	workers := make(chan struct{}, translationWorkers)
+	dispatcherDone := make(chan struct{})
+	dispatched := 0 // Written by the dispatcher, read once it is done
+
+	go func() {
+		defer close(dispatcherDone)
+		for index, chunk := range chunks {
+			select {
+			case workers <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			if ctx.Err() != nil {
+				// Both cases were ready and the worker slot won
+				return
+			}
+			dispatched++
+			go func(index int, chunk []string) {
+				defer func() { <-workers }()
+				translated, err := translateChunk(callCtx, index, chunk)
+				if err == nil && len(translated) != len(chunk) {
+					err = fmt.Errorf("provider returned %d translations for %d texts", len(translated), len(chunk))
+				}
+				resultsCh <- chunkResult{index: index, translated: translated, err: err}
+			}(index, chunk)
+		}
+	}()
+
+	var firstErr error
+	for received := 0; received < len(chunks); received++ {
+		select {
+		case result := <-resultsCh:
+			if result.err != nil {
+				if firstErr == nil {
+					firstErr = result.err
+				}
+				continue
+			}
+			onResult(result.index, result.translated)
+		case <-ctx.Done():
+			<-dispatcherDone
+			inFlight := dispatched - received
+			go func() {
+				defer cancelCalls()
+				for ; inFlight > 0; inFlight-- {
+					if result := <-resultsCh; result.err == nil && onLateResult != nil {
+						onLateResult(result.index, result.translated)
+					}
+				}
+			}()
+			return dispatched, ctx.Err()
+		}
+	}
+	cancelCalls()
+	return dispatched, firstErr
+}
+
+// chunkTexts splits texts into groups that respect the provider limits. A single text
+// longer than maxChars gets a chunk of its own.
+func chunkTexts(texts []string, limits batchLimits) [][]string {
+	var chunks [][]string
+	var current []string
+	currentChars := 0
+
+	for _, text := range texts {
+		length := len([]rune(text))
+		if len(current) > 0 && (len(current) >= limits.maxItems || currentChars+length > limits.maxChars) {
+			chunks = append(chunks, current)
+			current = nil
+			currentChars = 0
+		}
+		current = append(current, text)
+		currentChars += length
+	}
+	if len(current) > 0 {
+		chunks = append(chunks, current)
+	}
+	return chunks
+}
@@ -0,0 +1,176 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestBatchTranslate checks that a page of texts is translated in as few provider calls as possible
+func TestBatchTranslate(t *testing.T) {
+	var mutex sync.Mutex
+	var calls [][]string
+	translateChunk := func(ctx context.Context, chunk []string, format textFormat) ([]string, error) {
+		mutex.Lock()
+		calls = append(calls, chunk)
+		mutex.Unlock()
+		translated := make([]string, len(chunk))
+		for i, text := range chunk {
+			translated[i] = text + " [pt-BR]"
+		}
+		return translated, nil
+	}
+
+	cache := NewTranslationCache()
+	limits := batchLimits{maxItems: 50, maxChars: 100000}
+
+	// 40 APODs with a title and an explanation each, plus a repeated text
+	var texts []string
+	for i := 0; i < 40; i++ {
+		texts = append(texts, fmt.Sprintf("Title %d", i), fmt.Sprintf("Explanation %d", i))
+	}
+	texts = append(texts, "Title 0", "")
+
+	results, err := batchTranslate(context.Background(), cache, "test", texts, "en", "pt-BR", limits, translateChunk)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// 80 distinct texts with a limit of 50 per request means 2 calls
+	if len(calls) != 2 {
+		t.Errorf("Expected 2 provider calls, got %d", len(calls))
+	}
+	if results[0] != "Title 0 [pt-BR]" || results[80] != "Title 0 [pt-BR]" {
+		t.Errorf("Translations are not in the request order: %q, %q", results[0], results[80])
+	}
+	if results[81] != "" {
+		t.Errorf("Empty texts should not be translated, got %q", results[81])
+	}
+
+	// A second batch is served entirely from the cache
+	calls = nil
+	if _, err := batchTranslate(context.Background(), cache, "test", texts, "en", "pt-BR", limits, translateChunk); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(calls) != 0 {
+		t.Errorf("Expected no provider calls for cached texts, got %d", len(calls))
+	}
+}
+
+// TestBatchTranslateDeadline checks that an expired deadline returns the original texts
+func TestBatchTranslateDeadline(t *testing.T) {
+	slowChunk := func(ctx context.Context, chunk []string, format textFormat) ([]string, error) {
+		select {
+		case <-time.After(time.Second):
+			return chunk, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	texts := []string{"Amazing Galaxy", "This is a beautiful galaxy far away."}
+	start := time.Now()
+	results, err := batchTranslate(ctx, NewTranslationCache(), "test", texts, "en", "pt-BR", batchLimits{maxItems: 1, maxChars: 100}, slowChunk)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected a deadline error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Translation did not stop at the deadline, took %s", elapsed)
+	}
+	if results[0] != texts[0] || results[1] != texts[1] {
+		t.Errorf("Untranslated texts should be returned unchanged, got %v", results)
+	}
+}
+
+// TestBatchTranslateLateResults checks that chunks in flight at the deadline are counted
+// against the usage and cached once the provider answers
+func TestBatchTranslateLateResults(t *testing.T) {
+	useTestUsage(t, "")
+	release := make(chan struct{})
+	var mutex sync.Mutex
+	calls := 0
+	translateChunk := func(ctx context.Context, chunk []string, format textFormat) ([]string, error) {
+		mutex.Lock()
+		calls++
+		mutex.Unlock()
+		select {
+		case <-release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		translated := make([]string, len(chunk))
+		for i, text := range chunk {
+			translated[i] = text + " (pt)"
+		}
+		return translated, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	cache := NewTranslationCache()
+	limits := batchLimits{maxItems: 1, maxChars: 100}
+	texts := []string{"Nebula", "Galaxy"}
+	if _, err := batchTranslate(ctx, cache, ProviderDeepL, texts, "en", "pt-BR", limits, translateChunk); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected a deadline error, got %v", err)
+	}
+
+	// Both chunks were sent, so both are billed
+	day := usageNow()
+	report, err := GetTranslationUsageReport(context.Background(), day, day)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if report.Totals.Characters != 12 {
+		t.Errorf("Expected the 12 characters sent to be counted, got %+v", report.Totals)
+	}
+	month, err := usage.get(context.Background(), usageMonthKey(day))
+	if err != nil || month[BudgetScopeTotal] != 12 {
+		t.Errorf("Expected the 12 characters to stay reserved, got %v (%v)", month, err)
+	}
+
+	// The late answers are cached for the next request
+	close(release)
+	glossaryVersion := cacheGlossaryVersion("en", "pt-BR")
+	deadline := time.Now().Add(time.Second)
+	for _, text := range texts {
+		key := translationCacheKey(ProviderDeepL, "en", "pt-BR", glossaryVersion, text)
+		for {
+			if cached, found := cache.Get(key); found {
+				if cached != text+" (pt)" {
+					t.Errorf("Unexpected cached translation %q", cached)
+				}
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("Expected the late translation of %q to be cached", text)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+	results, err := batchTranslate(context.Background(), cache, ProviderDeepL, texts, "en", "pt-BR", limits, translateChunk)
+	if err != nil || results[0] != "Nebula (pt)" || results[1] != "Galaxy (pt)" {
+		t.Errorf("Expected the cached translations, got %v (%v)", results, err)
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	if calls != 2 {
+		t.Errorf("Expected no new provider calls, got %d", calls)
+	}
+}
+
+// TestChunkTexts checks that chunks respect the character limit
+func TestChunkTexts(t *testing.T) {
+	chunks := chunkTexts([]string{"aaaa", "bbbb", "cccccccccc", "d"}, batchLimits{maxItems: 10, maxChars: 8})
+	if len(chunks) != 3 {
+		t.Fatalf("Expected 3 chunks, got %d: %v", len(chunks), chunks)
+	}
+	if len(chunks[0]) != 2 || len(chunks[1]) != 1 || len(chunks[2]) != 1 {
+		t.Errorf("Unexpected chunks: %v", chunks)
+	}
+}
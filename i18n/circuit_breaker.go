@@ -0,0 +1,149 @@
+package i18n
+
+import (
+	"sync"
+	"time"
+)
+
+// Circuit breaker states
+const (
+	CircuitClosed   = "closed"    // Provider is healthy, calls go through
+	CircuitOpen     = "open"      // Provider is failing, calls are skipped until the cooldown ends
+	CircuitHalfOpen = "half-open" // Cooldown ended, a single trial call decides the next state
+)
+
+// CircuitBreaker stops calling a provider after consecutive failures and tracks its health
+type CircuitBreaker struct {
+	mutex            sync.Mutex
+	failureThreshold int           // Consecutive failures that open the circuit
+	cooldown         time.Duration // How long the circuit stays open before a trial call
+	now              func() time.Time
+
+	state               string
+	consecutiveFailures int
+	openedAt            time.Time
+	trialInFlight       bool
+
+	totalSuccesses int64
+	totalFailures  int64
+	lastSuccess    time.Time
+	lastFailure    time.Time
+	lastError      string
+}
+
+// CircuitBreakerStatus is a snapshot of a breaker's state and health counters
+type CircuitBreakerStatus struct {
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	TotalSuccesses      int64      `json:"total_successes"`
+	TotalFailures       int64      `json:"total_failures"`
+	LastSuccess         *time.Time `json:"last_success,omitempty"`
+	LastFailure         *time.Time `json:"last_failure,omitempty"`
+	LastError           string     `json:"last_error,omitempty"`
+	RetryAt             *time.Time `json:"retry_at,omitempty"`
+}
+
+// NewCircuitBreaker creates a closed circuit breaker
+func NewCircuitBreaker(failureThreshold int, cooldown time.Duration) *CircuitBreaker {
+	if failureThreshold < 1 {
+		failureThreshold = 1
+	}
+	return &CircuitBreaker{
+		failureThreshold: failureThreshold,
+		cooldown:         cooldown,
+		now:              time.Now,
+		state:            CircuitClosed,
+	}
+}
+
+// Allow reports whether a call may be made. Once the cooldown of an open circuit ends,
+// a single trial call is allowed and the others keep being skipped until it finishes.
+func (b *CircuitBreaker) Allow() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = CircuitHalfOpen
+		b.trialInFlight = true
+		return true
+	case CircuitHalfOpen:
+		if b.trialInFlight {
+			return false
+		}
+		b.trialInFlight = true
+		return true
+	default:
+		return true
+	}
+}
+
+// RecordSuccess closes the circuit
+func (b *CircuitBreaker) RecordSuccess() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.state = CircuitClosed
+	b.consecutiveFailures = 0
+	b.trialInFlight = false
+	b.totalSuccesses++
+	b.lastSuccess = b.now()
+}
+
+// RecordFailure counts a failed call, opening the circuit when the threshold is reached
+// or when a trial call fails
+func (b *CircuitBreaker) RecordFailure(err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.consecutiveFailures++
+	b.totalFailures++
+	b.lastFailure = b.now()
+	if err != nil {
+		b.lastError = err.Error()
+	}
+
+	if b.state == CircuitHalfOpen || b.consecutiveFailures >= b.failureThreshold {
+		b.state = CircuitOpen
+		b.openedAt = b.now()
+	}
+	b.trialInFlight = false
+}
+
+// RecordCanceled releases a call abandoned by the caller without counting it either way
+func (b *CircuitBreaker) RecordCanceled() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.trialInFlight = false
+}
+
+// Status returns a snapshot of the breaker
+func (b *CircuitBreaker) Status() CircuitBreakerStatus {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	status := CircuitBreakerStatus{
+		State:               b.state,
+		ConsecutiveFailures: b.consecutiveFailures,
+		TotalSuccesses:      b.totalSuccesses,
+		TotalFailures:       b.totalFailures,
+		LastError:           b.lastError,
+	}
+	if !b.lastSuccess.IsZero() {
+		lastSuccess := b.lastSuccess
+		status.LastSuccess = &lastSuccess
+	}
+	if !b.lastFailure.IsZero() {
+		lastFailure := b.lastFailure
+		status.LastFailure = &lastFailure
+	}
+	if b.state == CircuitOpen {
+		retryAt := b.openedAt.Add(b.cooldown)
+		status.RetryAt = &retryAt
+	}
+	return status
+}
@@ -0,0 +1,263 @@
+package i18n
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// deepLGlossaryRetry is how long a failed glossary creation is remembered before retrying
+const deepLGlossaryRetry = 10 * time.Minute
+
+// DeepLGlossaryRequest represents the request to create a DeepL glossary
+type DeepLGlossaryRequest struct {
+	Name          string `json:"name"`
+	SourceLang    string `json:"source_lang"`
+	TargetLang    string `json:"target_lang"`
+	Entries       string `json:"entries"`
+	EntriesFormat string `json:"entries_format"`
+}
+
+// DeepLGlossaryResponse represents a glossary returned by the DeepL API
+type DeepLGlossaryResponse struct {
+	GlossaryID string `json:"glossary_id"`
+	Name       string `json:"name"`
+	Ready      bool   `json:"ready"`
+}
+
+// DeepLGlossaryList represents the glossaries listed by the DeepL API
+type DeepLGlossaryList struct {
+	Glossaries []DeepLGlossaryResponse `json:"glossaries"`
+}
+
+// deepLGlossary is a glossary created on DeepL for one target language and glossary version
+type deepLGlossary struct {
+	version string
+	id      string
+	err     error
+	retryAt time.Time
+}
+
+// deepLGlossaries tracks the DeepL glossary of each target language
+type deepLGlossaries struct {
+	mutex  sync.Mutex
+	byLang map[string]deepLGlossary
+	// group coalesces the creation of the glossary of each language and version, so
+	// translations into other languages don't wait on it
+	group singleflight.Group
+}
+
+// newDeepLGlossaries creates an empty glossary registry
+func newDeepLGlossaries() *deepLGlossaries {
+	return &deepLGlossaries{byLang: make(map[string]deepLGlossary)}
+}
+
+// get returns the glossary known for a target language
+func (g *deepLGlossaries) get(targetLang string) (deepLGlossary, bool) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	glossary, ok := g.byLang[targetLang]
+	return glossary, ok
+}
+
+// set records the glossary of a target language
+func (g *deepLGlossaries) set(targetLang string, glossary deepLGlossary) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.byLang[targetLang] = glossary
+}
+
+// glossaryID returns the DeepL glossary for the target language, creating it when the
+// local glossary changed. A glossary of the same version left by an earlier process or
+// another replica is reused, and the ones of other versions are deleted.
+func (c *DeepLClient) glossaryID(ctx context.Context, glossary *Glossary, targetLang string) (string, error) {
+	version := glossary.Version()
+	existing, ok := c.glossaries.get(targetLang)
+	if ok && existing.version == version {
+		if existing.err == nil {
+			return existing.id, nil
+		}
+		if time.Now().Before(existing.retryAt) {
+			return "", existing.err
+		}
+	}
+
+	id, err, _ := c.glossaries.group.Do(targetLang+"|"+version, func() (interface{}, error) {
+		// Another call may have finished creating it while this one waited
+		if current, ok := c.glossaries.get(targetLang); ok && current.version == version && current.err == nil {
+			return current.id, nil
+		}
+
+		id, err := c.findOrCreateGlossary(ctx, glossary, targetLang)
+		if err != nil {
+			c.glossaries.set(targetLang, deepLGlossary{
+				version: version,
+				err:     err,
+				retryAt: time.Now().Add(deepLGlossaryRetry),
+			})
+			return "", err
+		}
+		c.glossaries.set(targetLang, deepLGlossary{version: version, id: id})
+		return id, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return id.(string), nil
+}
+
+// findOrCreateGlossary looks for the glossary of the target language on DeepL, creating
+// it if missing, and deletes the glossaries this API created for other versions
+func (c *DeepLClient) findOrCreateGlossary(ctx context.Context, glossary *Glossary, targetLang string) (string, error) {
+	name := deepLGlossaryName(targetLang, glossary.Version())
+	stored, err := c.listGlossaries(ctx)
+	if err != nil {
+		// Creating a new glossary still works, the stale ones are deleted next time
+		log.Printf("Error listing DeepL glossaries: %v", err)
+	}
+
+	id := ""
+	for _, candidate := range stored {
+		switch {
+		case candidate.Name == name && candidate.Ready && id == "":
+			id = candidate.GlossaryID
+		case isDeepLGlossaryOf(candidate.Name, targetLang):
+			go c.deleteGlossary(candidate.GlossaryID)
+		}
+	}
+	if id != "" {
+		return id, nil
+	}
+	return c.createGlossary(ctx, glossary, targetLang)
+}
+
+// listGlossaries returns every glossary of the DeepL account
+func (c *DeepLClient) listGlossaries(ctx context.Context) ([]DeepLGlossaryResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL()+"/v2/glossaries", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+	req.Header.Set("Authorization", c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error executing request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("glossary API returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var list DeepLGlossaryList
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, fmt.Errorf("error decoding glossary list: %v", err)
+	}
+	return list.Glossaries, nil
+}
+
+// createGlossary uploads the terms of a target language as a DeepL glossary
+func (c *DeepLClient) createGlossary(ctx context.Context, glossary *Glossary, targetLang string) (string, error) {
+	terms := glossary.Terms(targetLang)
+	sources := make([]string, 0, len(terms))
+	for term := range terms {
+		sources = append(sources, term)
+	}
+	sort.Strings(sources)
+
+	var entries strings.Builder
+	for _, term := range sources {
+		fmt.Fprintf(&entries, "%s\t%s\n", term, terms[term])
+	}
+
+	reqBody := DeepLGlossaryRequest{
+		Name:          deepLGlossaryName(targetLang, glossary.Version()),
+		SourceLang:    "en",
+		TargetLang:    deepLGlossaryLanguage(targetLang),
+		Entries:       entries.String(),
+		EntriesFormat: "tsv",
+	}
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return "", fmt.Errorf("error serializing glossary: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL()+"/v2/glossaries", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", fmt.Errorf("error creating request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error executing request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("glossary API returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var glossaryResp DeepLGlossaryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&glossaryResp); err != nil {
+		return "", fmt.Errorf("error decoding glossary response: %v", err)
+	}
+	if glossaryResp.GlossaryID == "" {
+		return "", fmt.Errorf("no glossary ID returned")
+	}
+
+	log.Printf("Created DeepL glossary %s for %s (version %s)", glossaryResp.GlossaryID, targetLang, glossary.Version())
+	return glossaryResp.GlossaryID, nil
+}
+
+// deleteGlossary removes an outdated glossary from DeepL
+func (c *DeepLClient) deleteGlossary(id string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.baseURL()+"/v2/glossaries/"+id, nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("Authorization", c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		log.Printf("Error deleting DeepL glossary %s: %v", id, err)
+		return
+	}
+	resp.Body.Close()
+}
+
+// deepLGlossaryName names the glossary of a target language and glossary version
+func deepLGlossaryName(targetLang, version string) string {
+	return "astrovista-" + targetLang + "-" + version
+}
+
+// isDeepLGlossaryOf reports whether a DeepL glossary was created by this API for the
+// target language, in any version. Versions are hex, so "zh-Hant" glossaries don't
+// match "zh".
+func isDeepLGlossaryOf(name, targetLang string) bool {
+	version, ok := strings.CutPrefix(name, "astrovista-"+targetLang+"-")
+	return ok && version != "" && !strings.Contains(version, "-")
+}
+
+// deepLGlossaryLanguage converts a language code to the form used by DeepL glossaries,
+// which have no regional variants (e.g. "pt-BR" -> "pt")
+func deepLGlossaryLanguage(lang string) string {
+	base, _, _ := strings.Cut(lang, "-")
+	return strings.ToLower(base)
+}
@@ -0,0 +1,161 @@
+package i18n
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// deepLGlossaryStandIn is a local stand-in for the glossary endpoints of the DeepL API
+type deepLGlossaryStandIn struct {
+	mutex      sync.Mutex
+	glossaries map[string]DeepLGlossaryResponse
+	created    map[string]int // Creations per target language
+	deleted    []string
+	slowLang   string        // Creations for this language wait for release
+	release    chan struct{} // Closed to let slow creations finish
+}
+
+func newDeepLGlossaryStandIn(existing ...DeepLGlossaryResponse) *deepLGlossaryStandIn {
+	s := &deepLGlossaryStandIn{
+		glossaries: make(map[string]DeepLGlossaryResponse),
+		created:    make(map[string]int),
+		release:    make(chan struct{}),
+	}
+	for _, glossary := range existing {
+		s.glossaries[glossary.GlossaryID] = glossary
+	}
+	return s
+}
+
+func (s *deepLGlossaryStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == "GET" && r.URL.Path == "/v2/glossaries":
+		s.mutex.Lock()
+		list := DeepLGlossaryList{Glossaries: []DeepLGlossaryResponse{}}
+		for _, glossary := range s.glossaries {
+			list.Glossaries = append(list.Glossaries, glossary)
+		}
+		s.mutex.Unlock()
+		json.NewEncoder(w).Encode(list)
+	case r.Method == "POST" && r.URL.Path == "/v2/glossaries":
+		var req DeepLGlossaryRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		lang := strings.TrimPrefix(req.Name, "astrovista-")
+		lang = lang[:strings.LastIndex(lang, "-")]
+		if lang == s.slowLang {
+			<-s.release
+		}
+		s.mutex.Lock()
+		s.created[lang]++
+		glossary := DeepLGlossaryResponse{GlossaryID: fmt.Sprintf("%s-%d", lang, s.created[lang]), Name: req.Name, Ready: true}
+		s.glossaries[glossary.GlossaryID] = glossary
+		s.mutex.Unlock()
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(glossary)
+	case r.Method == "DELETE" && strings.HasPrefix(r.URL.Path, "/v2/glossaries/"):
+		id := strings.TrimPrefix(r.URL.Path, "/v2/glossaries/")
+		s.mutex.Lock()
+		delete(s.glossaries, id)
+		s.deleted = append(s.deleted, id)
+		s.mutex.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *deepLGlossaryStandIn) creations(lang string) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.created[lang]
+}
+
+func (s *deepLGlossaryStandIn) deletions() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]string(nil), s.deleted...)
+}
+
+// TestDeepLGlossaryCreation checks that concurrent calls create a language's glossary once,
+// without holding up the other languages
+func TestDeepLGlossaryCreation(t *testing.T) {
+	standIn := newDeepLGlossaryStandIn()
+	standIn.slowLang = "de"
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+	client := NewDeepLClient("test-key")
+	client.endpoint = server.URL
+	glossary := NewGlossary([]GlossaryEntry{{Term: "Hubble"}})
+
+	// Several requests wait for the slow German glossary
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if id, err := client.glossaryID(context.Background(), glossary, "de"); err != nil || id != "de-1" {
+				t.Errorf("Expected glossary de-1, got %q (%v)", id, err)
+			}
+		}()
+	}
+
+	// Meanwhile another language gets its glossary
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if id, err := client.glossaryID(context.Background(), glossary, "pt-BR"); err != nil || id != "pt-BR-1" {
+			t.Errorf("Expected glossary pt-BR-1, got %q (%v)", id, err)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("A slow glossary creation should not hold up other languages")
+	}
+
+	close(standIn.release)
+	wg.Wait()
+	if n := standIn.creations("de"); n != 1 {
+		t.Errorf("Expected a single German glossary to be created, got %d", n)
+	}
+}
+
+// TestDeepLGlossaryReuse checks that a glossary left by another process is reused and the
+// glossaries of other versions are deleted
+func TestDeepLGlossaryReuse(t *testing.T) {
+	glossary := NewGlossary([]GlossaryEntry{{Term: "Hubble"}})
+	standIn := newDeepLGlossaryStandIn(
+		DeepLGlossaryResponse{GlossaryID: "current", Name: deepLGlossaryName("zh", glossary.Version()), Ready: true},
+		DeepLGlossaryResponse{GlossaryID: "stale", Name: deepLGlossaryName("zh", "0123456789ab"), Ready: true},
+		DeepLGlossaryResponse{GlossaryID: "other-language", Name: deepLGlossaryName("zh-Hant", "0123456789ab"), Ready: true},
+		DeepLGlossaryResponse{GlossaryID: "not-ours", Name: "marketing terms", Ready: true},
+	)
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+	client := NewDeepLClient("test-key")
+	client.endpoint = server.URL
+
+	id, err := client.glossaryID(context.Background(), glossary, "zh")
+	if err != nil || id != "current" {
+		t.Fatalf("Expected the existing glossary to be reused, got %q (%v)", id, err)
+	}
+	if n := standIn.creations("zh"); n != 0 {
+		t.Errorf("Expected no glossary to be created, got %d", n)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for len(standIn.deletions()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond) // Let any wrong deletion land too
+	if deleted := standIn.deletions(); len(deleted) != 1 || deleted[0] != "stale" {
+		t.Errorf("Expected only the stale glossary to be deleted, got %v", deleted)
+	}
+}
@@ -2,8 +2,10 @@ package i18n
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"net/http"
 	"os"
 	"strings"
@@ -16,6 +18,9 @@ type DeepLTranslateRequest struct {
 	SourceLang string   `json:"source_lang,omitempty"`
 	TargetLang string   `json:"target_lang"`
 	Formality  string   `json:"formality,omitempty"`
+	GlossaryID string   `json:"glossary_id,omitempty"`
+	// "html" makes DeepL translate only the text between tags
+	TagHandling string `json:"tag_handling,omitempty"`
 }
 
 // DeepLTranslateResponse represents the response from the DeepL API
@@ -30,7 +35,9 @@ type DeepLClient struct {
 	apiKey     string
 	httpClient *http.Client
 	cache      *TranslationCache
-	freeAPI    bool // Indicates whether using the free API or Pro
+	freeAPI    bool   // Indicates whether using the free API or Pro
+	endpoint   string // Overrides the API URL (used in tests)
+	glossaries *deepLGlossaries
 }
 
 // NewDeepLClient creates a new client for the DeepL API
@@ -43,49 +50,105 @@ func NewDeepLClient(apiKey string) *DeepLClient {
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
-		cache:   NewTranslationCache(),
-		freeAPI: isFreeAPI,
+		cache:      NewTranslationCache(),
+		freeAPI:    isFreeAPI,
+		glossaries: newDeepLGlossaries(),
 	}
 }
 
+// deepLBatchLimits are the DeepL limits per request
+var deepLBatchLimits = batchLimits{
+	maxItems: 50,     // Maximum number of "text" parameters
+	maxChars: 100000, // Keeps the request well under the 128 KiB body limit
+}
+
 // Translate implements the TranslationService interface for DeepL
-func (c *DeepLClient) Translate(text, sourceLang, targetLang string) (string, error) {
-	// Check the cache first
-	cacheKey := fmt.Sprintf("deepl:%s:%s:%s", sourceLang, targetLang, getHashKey(text))
-	if cachedText, found := c.cache.Get(cacheKey); found {
-		return cachedText, nil
+func (c *DeepLClient) Translate(ctx context.Context, text, sourceLang, targetLang string) (string, error) {
+	translated, err := c.TranslateBatch(ctx, []string{text}, sourceLang, targetLang)
+	if err != nil {
+		return "", err
 	}
+	return translated[0], nil
+}
 
-	// Adapt the language code to the format expected by DeepL
-	targetLang = adaptLanguageForDeepL(targetLang)
+// TranslateBatch implements the TranslationService interface for DeepL,
+// sending up to 50 texts per request. The glossary is applied through a DeepL
+// glossary, or by masking the terms if DeepL can't create one for the language.
+func (c *DeepLClient) TranslateBatch(ctx context.Context, texts []string, sourceLang, targetLang string) ([]string, error) {
+	glossary := CurrentGlossary()
+	if !glossary.Applies(sourceLang, targetLang) {
+		return c.translateBatch(ctx, texts, sourceLang, targetLang, "")
+	}
+
+	glossaryID, err := c.glossaryID(ctx, glossary, targetLang)
+	if err != nil {
+		log.Printf("DeepL glossary unavailable for %s, masking terms instead: %v", targetLang, err)
+		return translateMasked(ctx, glossary, texts, targetLang, func(ctx context.Context, masked []string) ([]string, error) {
+			return c.translateBatch(ctx, masked, sourceLang, targetLang, "")
+		})
+	}
+	return c.translateBatch(ctx, texts, sourceLang, targetLang, glossaryID)
+}
+
+// translateBatch translates texts with an optional DeepL glossary
+func (c *DeepLClient) translateBatch(ctx context.Context, texts []string, sourceLang, targetLang, glossaryID string) ([]string, error) {
+	return batchTranslate(ctx, c.cache, ProviderDeepL, texts, sourceLang, targetLang, deepLBatchLimits, func(ctx context.Context, chunk []string, format textFormat) ([]string, error) {
+		return c.translateChunk(ctx, chunk, format, sourceLang, targetLang, glossaryID)
+	})
+}
+
+// cachedTranslation returns a translation from the cache without calling the API
+func (c *DeepLClient) cachedTranslation(text, sourceLang, targetLang string) (string, bool) {
+	return c.cache.Get(translationCacheKey(ProviderDeepL, sourceLang, targetLang, cacheGlossaryVersion(sourceLang, targetLang), text))
+}
 
-	// Prepare the request
+// cacheStats reports the statistics of the translation cache
+func (c *DeepLClient) cacheStats() TranslationCacheStats {
+	return c.cache.Stats()
+}
+
+// baseURL returns the API URL depending on the type (Free or Pro)
+func (c *DeepLClient) baseURL() string {
+	if c.endpoint != "" {
+		return c.endpoint
+	}
+	if c.freeAPI {
+		return "https://api-free.deepl.com"
+	}
+	return "https://api.deepl.com"
+}
+
+// translateChunk sends one request to the DeepL API
+func (c *DeepLClient) translateChunk(ctx context.Context, texts []string, format textFormat, sourceLang, targetLang, glossaryID string) ([]string, error) {
+	// Prepare the request, adapting the language code to the format expected by DeepL
 	reqBody := DeepLTranslateRequest{
-		Text:       []string{text},
-		TargetLang: targetLang,
+		Text:       texts,
+		TargetLang: providerLanguageCode(ProviderDeepL, targetLang, adaptLanguageForDeepL),
+		GlossaryID: glossaryID,
+	}
+	if format == formatHTML {
+		reqBody.TagHandling = "html"
 	}
 
-	// Only define the source language if specified
+	// Only define the source language if specified (always required with a glossary).
+	// Source languages have no regional variants on DeepL (e.g. "PT", not "PT-BR").
 	if sourceLang != "" {
-		reqBody.SourceLang = adaptLanguageForDeepL(sourceLang)
+		reqBody.SourceLang = strings.ToUpper(sanitizeLanguageCode(sourceLang))
+	} else if glossaryID != "" {
+		reqBody.SourceLang = "EN"
 	}
 
 	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
-		return "", fmt.Errorf("error serializing request: %v", err)
+		return nil, fmt.Errorf("error serializing request: %v", err)
 	}
 
 	// API URL depending on the type (Free or Pro)
-	var url string
-	if c.freeAPI {
-		url = "https://api-free.deepl.com/v2/translate"
-	} else {
-		url = "https://api.deepl.com/v2/translate"
-	}
+	url := c.baseURL() + "/v2/translate"
 	// Create the HTTP request
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return "", fmt.Errorf("error creating request: %v", err)
+		return nil, fmt.Errorf("error creating request: %v", err)
 	}
 
 	// Set the headers
@@ -95,32 +158,32 @@ func (c *DeepLClient) Translate(text, sourceLang, targetLang string) (string, er
 	// Execute the request
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("error executing request: %v", err)
+		return nil, fmt.Errorf("error executing request: %v", err)
 	}
 	defer resp.Body.Close()
 
 	// Check the response status
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("API returned non-OK status: %d", resp.StatusCode)
+		return nil, fmt.Errorf("API returned non-OK status: %d", resp.StatusCode)
 	}
 
 	// Decode the response
 	var translateResp DeepLTranslateResponse
 	if err := json.NewDecoder(resp.Body).Decode(&translateResp); err != nil {
-		return "", fmt.Errorf("error decoding response: %v", err)
+		return nil, fmt.Errorf("error decoding response: %v", err)
 	}
 
 	// Check if there are translations
 	if len(translateResp.Translations) == 0 {
-		return "", fmt.Errorf("no translation returned")
+		return nil, fmt.Errorf("no translation returned")
+	}
+	// Get the translated texts, in the same order as the request
+	translated := make([]string, 0, len(translateResp.Translations))
+	for _, translation := range translateResp.Translations {
+		translated = append(translated, translation.Text)
 	}
-	// Get the translated text
-	translatedText := translateResp.Translations[0].Text
-
-	// Store in cache
-	c.cache.Set(cacheKey, translatedText)
 
-	return translatedText, nil
+	return translated, nil
 }
 
 // adaptLanguageForDeepL converts language codes to the format expected by DeepL
@@ -0,0 +1,135 @@
+package i18n
+
+import (
+	"strings"
+	"unicode"
+)
+
+// minDetectionWords is how many common words a Latin-script text needs before its language is trusted
+const minDetectionWords = 2
+
+// commonWords are frequent short words of the supported Latin-script languages, which
+// tell them apart in texts of a sentence or more
+var commonWords = map[string][]string{
+	"en":    {"the", "and", "of", "to", "is", "in", "that", "this", "with", "from", "are", "was", "which", "by", "its", "an", "as", "on", "for", "it"},
+	"es":    {"el", "la", "los", "las", "de", "del", "que", "y", "en", "un", "una", "es", "por", "con", "se", "su", "al", "más", "como", "esta"},
+	"fr":    {"le", "la", "les", "des", "de", "du", "et", "est", "un", "une", "dans", "que", "qui", "sur", "pour", "avec", "au", "aux", "ce", "cette"},
+	"de":    {"der", "die", "das", "und", "ist", "den", "dem", "des", "ein", "eine", "mit", "von", "im", "auf", "nicht", "sich", "zu", "auch", "wird", "diese"},
+	"pt-BR": {"o", "os", "as", "do", "da", "dos", "das", "de", "e", "é", "um", "uma", "em", "no", "na", "que", "com", "não", "mais", "essa"},
+	"it":    {"il", "lo", "la", "gli", "le", "di", "del", "della", "che", "e", "è", "un", "una", "per", "con", "non", "nel", "sono", "questa", "si"},
+	"nl":    {"de", "het", "een", "en", "van", "is", "dat", "op", "te", "met", "zijn", "die", "voor", "niet", "ook", "aan", "er", "deze", "wordt", "bij"},
+	"pl":    {"i", "w", "z", "na", "się", "nie", "jest", "to", "że", "do", "jak", "od", "po", "przez", "dla", "są", "oraz", "ten", "ta", "tym"},
+	"tr":    {"ve", "bir", "bu", "da", "için", "ile", "olan", "olarak", "gibi", "çok", "daha", "ise", "kadar", "ama", "şu", "olduğu", "üzerinde", "ki", "ne", "her"},
+	"vi":    {"và", "của", "là", "các", "có", "được", "trong", "một", "những", "cho", "này", "với", "không", "người", "đã", "từ", "sao", "thiên", "hà", "ánh"},
+	"id":    {"dan", "yang", "di", "ini", "itu", "dengan", "untuk", "dari", "dalam", "adalah", "pada", "tidak", "akan", "ke", "juga", "sebuah", "oleh", "atau", "dapat", "bintang"},
+	"cs":    {"a", "je", "v", "se", "na", "že", "to", "s", "z", "do", "jako", "ale", "jsou", "pro", "které", "který", "také", "by", "této", "tato"},
+	"hu":    {"a", "az", "és", "egy", "hogy", "nem", "is", "van", "meg", "de", "ez", "volt", "már", "csak", "mint", "vagy", "ezen", "ahol", "amely", "között"},
+	"ro":    {"și", "în", "la", "cu", "pe", "un", "o", "este", "care", "din", "mai", "nu", "se", "pentru", "sunt", "al", "acest", "această", "fi", "lui"},
+	"sv":    {"och", "att", "det", "som", "en", "är", "av", "för", "med", "till", "den", "på", "inte", "har", "ett", "om", "var", "denna", "från", "kan"},
+}
+
+// commonWordLanguages indexes commonWords by word
+var commonWordLanguages = func() map[string][]string {
+	index := make(map[string][]string)
+	for lang, words := range commonWords {
+		for _, word := range words {
+			index[word] = append(index[word], lang)
+		}
+	}
+	return index
+}()
+
+// DetectLanguage guesses the supported language a text is written in. Languages with their
+// own script (Japanese, Chinese, Korean, Arabic, Persian, Russian, Ukrainian) are told apart
+// by their letters; Latin-script languages by counting their most common words. It returns
+// false when the text is too short or too ambiguous to tell, e.g. a title made of names.
+func DetectLanguage(text string) (string, bool) {
+	var latin, han, kana, hangul, arabic, persian, cyrillic, ukrainian int
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r):
+			kana++
+		case unicode.Is(unicode.Han, r):
+			han++
+		case unicode.Is(unicode.Hangul, r):
+			hangul++
+		case unicode.Is(unicode.Arabic, r):
+			arabic++
+			if strings.ContainsRune("پچژگکی", r) {
+				persian++
+			}
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+			if strings.ContainsRune("іїєґІЇЄҐ", r) {
+				ukrainian++
+			}
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		}
+	}
+
+	// The script with the most letters decides, Latin only wins when nothing else is used much
+	switch max(kana+han, hangul, arabic, cyrillic, latin) {
+	case 0:
+		return "", false
+	case kana + han:
+		if kana > 0 {
+			return "ja", true
+		}
+		return "zh", true
+	case hangul:
+		return "ko", true
+	case arabic:
+		if persian > 0 {
+			return "fa", true
+		}
+		return "ar", true
+	case cyrillic:
+		if ukrainian > 0 {
+			return "uk", true
+		}
+		return "ru", true
+	}
+	return detectLatinLanguage(text)
+}
+
+// detectLatinLanguage picks the Latin-script language whose common words appear the most
+func detectLatinLanguage(text string) (string, bool) {
+	scores := make(map[string]int)
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for _, word := range words {
+		for _, lang := range commonWordLanguages[word] {
+			scores[lang]++
+		}
+	}
+
+	best, bestScore, runnerUp := "", 0, 0
+	for _, lang := range SupportedLanguages() {
+		score := scores[lang]
+		if score > bestScore {
+			best, bestScore, runnerUp = lang, score, bestScore
+		} else if score > runnerUp {
+			runnerUp = score
+		}
+	}
+	if bestScore < minDetectionWords || bestScore == runnerUp {
+		return "", false
+	}
+	return best, true
+}
+
+// SourceLanguage resolves the language an APOD is written in: the declared language when it
+// is supported, otherwise the language detected from its texts, and English when neither works
+func SourceLanguage(declared string, texts ...string) string {
+	if declared != "" {
+		if lang, err := ParseLanguage(declared); err == nil {
+			return lang
+		}
+	}
+	if lang, ok := DetectLanguage(strings.Join(texts, "\n")); ok {
+		return lang
+	}
+	return "en"
+}
@@ -0,0 +1,60 @@
+package i18n
+
+import "testing"
+
+// TestDetectLanguage checks the detection of APOD-like texts and that short names are not guessed
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"This is the Andromeda Galaxy, the nearest large spiral galaxy to the Milky Way.", "en"},
+		{"Esta es la galaxia de Andrómeda, la gran galaxia espiral más cercana a la Vía Láctea.", "es"},
+		{"Esta é a galáxia de Andrômeda, a grande galáxia espiral mais próxima da Via Láctea.", "pt-BR"},
+		{"C'est la galaxie d'Andromède, la grande galaxie spirale la plus proche de la Voie lactée.", "fr"},
+		{"Das ist die Andromedagalaxie, die nächste große Spiralgalaxie und ein Nachbar der Milchstraße.", "de"},
+		{"Questa è la galassia di Andromeda, la grande galassia a spirale più vicina alla Via Lattea.", "it"},
+		{"Dit is het Andromedastelsel, het dichtstbijzijnde grote spiraalstelsel van de Melkweg.", "nl"},
+		{"Detta är Andromedagalaxen, den närmaste stora spiralgalaxen och en granne till Vintergatan.", "sv"},
+		{"アンドロメダ銀河は天の川銀河に最も近い大きな渦巻銀河です。", "ja"},
+		{"仙女座星系是距离银河系最近的大型螺旋星系。", "zh"},
+		{"안드로메다 은하는 우리 은하에서 가장 가까운 나선 은하입니다.", "ko"},
+		{"Галактика Андромеды — ближайшая к Млечному Пути большая спиральная галактика.", "ru"},
+		{"Галактика Андромеди — найближча до Чумацького Шляху велика спіральна галактика.", "uk"},
+		{"مجرة المرأة المسلسلة هي أقرب مجرة حلزونية كبيرة إلى درب التبانة.", "ar"},
+		{"کهکشان آندرومدا نزدیک‌ترین کهکشان مارپیچی بزرگ به کهکشان راه شیری است.", "fa"},
+	}
+	for _, test := range tests {
+		if lang, ok := DetectLanguage(test.text); !ok || lang != test.expected {
+			t.Errorf("DetectLanguage(%q) = %q, %v; expected %q", test.text, lang, ok, test.expected)
+		}
+	}
+
+	for _, text := range []string{"", "M31", "Andromeda Galaxy", "NGC 7000"} {
+		if lang, ok := DetectLanguage(text); ok {
+			t.Errorf("DetectLanguage(%q) = %q; expected no language", text, lang)
+		}
+	}
+}
+
+// TestSourceLanguage checks that a supported declared language wins over detection
+func TestSourceLanguage(t *testing.T) {
+	spanish := "La galaxia de Andrómeda es la galaxia espiral más cercana a la Vía Láctea."
+	tests := []struct {
+		declared string
+		expected string
+	}{
+		{"es-MX", "es"},
+		{"", "es"},
+		{"not a language", "es"},
+		{"de", "de"},
+	}
+	for _, test := range tests {
+		if lang := SourceLanguage(test.declared, "Andrómeda", spanish); lang != test.expected {
+			t.Errorf("SourceLanguage(%q) = %q; expected %q", test.declared, lang, test.expected)
+		}
+	}
+	if lang := SourceLanguage("", "M31"); lang != "en" {
+		t.Errorf("Expected English when nothing can be detected, got %q", lang)
+	}
+}
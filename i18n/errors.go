@@ -0,0 +1,140 @@
+package i18n
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// ErrorCode is a stable, machine-readable identifier of an API error. It is also the
+// ID of the error's message in the locale files.
+type ErrorCode string
+
+// Error codes returned by the API
+const (
+	// APODs
+	CodeAPODNotFound       ErrorCode = "apod_not_found"
+	CodeAPODAlreadyExists  ErrorCode = "apod_already_exists"
+	CodeNoDocumentsFound   ErrorCode = "no_documents_found"
+	CodeSearchNoResults    ErrorCode = "search_no_results"
+	CodeDateRangeNoResults ErrorCode = "date_range_no_results"
+	CodeInvalidDate        ErrorCode = "invalid_date"
+	CodeInvalidMediaType   ErrorCode = "invalid_media_type"
+	CodeDateRangeError     ErrorCode = "date_range_error"
+	CodeNASAAPIError       ErrorCode = "nasa_api_error"
+
+	// Requests
+	CodeUnsupportedLanguage ErrorCode = "unsupported_language"
+	CodeInvalidRequestBody  ErrorCode = "invalid_request_body"
+	CodeInvalidID           ErrorCode = "invalid_id"
+	CodeRateLimitExceeded   ErrorCode = "rate_limit_exceeded"
+
+	// Authentication
+	CodeUnauthorized  ErrorCode = "unauthorized"
+	CodeForbidden     ErrorCode = "forbidden"
+	CodeInvalidAPIKey ErrorCode = "invalid_api_key"
+	CodeQuotaExceeded ErrorCode = "quota_exceeded"
+	CodeAuthError     ErrorCode = "auth_error"
+
+	// API key administration
+	CodeAPIKeyNameRequired ErrorCode = "api_key_name_required"
+	CodeAPIKeyNotFound     ErrorCode = "api_key_not_found"
+	CodeAPIKeyError        ErrorCode = "api_key_error"
+
+	// Translation administration
+	CodeInvalidTranslationField ErrorCode = "invalid_translation_field"
+	CodeTranslationTextRequired ErrorCode = "translation_text_required"
+	CodeTranslationNotFound     ErrorCode = "translation_not_found"
+	CodeNoDraftToApprove        ErrorCode = "no_draft_to_approve"
+	CodeTranslationError        ErrorCode = "translation_error"
+	CodeGlossaryEntryNotFound   ErrorCode = "glossary_entry_not_found"
+	CodeInvalidGlossaryEntry    ErrorCode = "invalid_glossary_entry"
+	CodeGlossaryError           ErrorCode = "glossary_error"
+
+	// Server
+	CodeDatabaseError ErrorCode = "database_error"
+	CodeGeneralError  ErrorCode = "general_error"
+)
+
+// errorMessages holds the English message of every error code, used when a locale has no translation
+var errorMessages = map[ErrorCode]string{
+	CodeAPODNotFound:       "Document not found! Please check the date format (YYYY-MM-DD).",
+	CodeAPODAlreadyExists:  "An APOD already exists for this date.",
+	CodeNoDocumentsFound:   "No documents found.",
+	CodeSearchNoResults:    "No documents found matching the search criteria",
+	CodeDateRangeNoResults: "No documents found for the given date range.",
+	CodeInvalidDate:        "Invalid date. Use YYYY-MM-DD format.",
+	CodeInvalidMediaType:   "Invalid media type. Use 'image', 'video', or 'any'.",
+	CodeDateRangeError:     "Date range error. Start date must be earlier than end date.",
+	CodeNASAAPIError:       "Error fetching data from the NASA API.",
+
+	CodeUnsupportedLanguage: "Unsupported language.",
+	CodeInvalidRequestBody:  "Invalid request body.",
+	CodeInvalidID:           "Invalid ID.",
+	CodeRateLimitExceeded:   "Rate limit exceeded. Please try again later.",
+
+	CodeUnauthorized:  "Unauthorized - valid credentials required.",
+	CodeForbidden:     "Forbidden - missing required role.",
+	CodeInvalidAPIKey: "Invalid or revoked API key.",
+	CodeQuotaExceeded: "Quota exceeded. Use an API key with a higher quota or try again later.",
+	CodeAuthError:     "Error validating credentials.",
+
+	CodeAPIKeyNameRequired: "The key name is required.",
+	CodeAPIKeyNotFound:     "API key not found.",
+	CodeAPIKeyError:        "Error processing the API key.",
+
+	CodeInvalidTranslationField: "Invalid translation field. Use 'title', 'explanation' or 'copyright'.",
+	CodeTranslationTextRequired: "The translation text is required.",
+	CodeTranslationNotFound:     "Translation not found.",
+	CodeNoDraftToApprove:        "No draft translation to approve.",
+	CodeTranslationError:        "Error processing the translation.",
+	CodeGlossaryEntryNotFound:   "Glossary entry not found.",
+	CodeInvalidGlossaryEntry:    "Invalid glossary entry.",
+	CodeGlossaryError:           "Error processing the glossary.",
+
+	CodeDatabaseError: "Database error. Please try again later.",
+	CodeGeneralError:  "Server error. Please try again later.",
+}
+
+// ErrorResponse is the body of every error returned by the API
+type ErrorResponse struct {
+	// Stable, machine-readable error code
+	// example: apod_not_found
+	Code ErrorCode `json:"code"`
+	// Error message in the language of the request
+	// example: Document not found! Please check the date format (YYYY-MM-DD).
+	Error string `json:"error"`
+	// Technical details, in English
+	Details string `json:"details,omitempty"`
+}
+
+// ErrorCodes returns every error code of the catalogue
+func ErrorCodes() []ErrorCode {
+	codes := make([]ErrorCode, 0, len(errorMessages))
+	for code := range errorMessages {
+		codes = append(codes, code)
+	}
+	return codes
+}
+
+// Message returns the message of the error code in the given language, falling back to English
+func (c ErrorCode) Message(lang string) string {
+	defaultMessage := &i18n.Message{ID: string(c), Other: errorMessages[c]}
+	message, err := Localizer(lang).Localize(&i18n.LocalizeConfig{DefaultMessage: defaultMessage})
+	if err != nil || message == "" {
+		return defaultMessage.Other
+	}
+	return message
+}
+
+// WriteError writes an error response with the message of the code in the given language
+func WriteError(w http.ResponseWriter, lang string, status int, code ErrorCode, details string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(ErrorResponse{
+		Code:    code,
+		Error:   code.Message(lang),
+		Details: details,
+	})
+}
@@ -0,0 +1,105 @@
+package i18n_test
+
+import (
+	"astrovista-api/i18n"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestLocaleFilesHaveEveryKey checks that every locale translates every message of the
+// English locale and every error code
+func TestLocaleFilesHaveEveryKey(t *testing.T) {
+	english := readLocaleFile(t, "en")
+	for _, code := range i18n.ErrorCodes() {
+		if _, ok := english[string(code)]; !ok {
+			t.Errorf("en.json is missing error code %q", code)
+		}
+	}
+
+	for _, lang := range i18n.SupportedLanguages() {
+		messages := readLocaleFile(t, lang)
+		for key := range english {
+			if messages[key]["other"] == "" {
+				t.Errorf("%s.json is missing %q", lang, key)
+			}
+		}
+	}
+}
+
+// TestWriteErrorLocalized checks that error responses carry the code and the localized message
+func TestWriteErrorLocalized(t *testing.T) {
+	// Only English and Brazilian Portuguese are loaded
+	dir := t.TempDir()
+	for _, lang := range []string{"en", "pt-BR"} {
+		data, err := os.ReadFile(filepath.Join("locales", lang+".json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, lang+".json"), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := i18n.LoadLocales(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer i18n.LoadLocales("")
+
+	testCases := []struct {
+		lang     string
+		expected string
+	}{
+		{"pt-BR", readLocaleFile(t, "pt-BR")["rate_limit_exceeded"]["other"]},
+		{"en", "Rate limit exceeded. Please try again later."},
+		// Languages without a loaded locale fall back to English
+		{"ja", "Rate limit exceeded. Please try again later."},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.lang, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			i18n.WriteError(rr, tc.lang, http.StatusTooManyRequests, i18n.CodeRateLimitExceeded, "")
+
+			if rr.Code != http.StatusTooManyRequests {
+				t.Errorf("Expected status %d, got %d", http.StatusTooManyRequests, rr.Code)
+			}
+			var response i18n.ErrorResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+				t.Fatal(err)
+			}
+			if response.Code != i18n.CodeRateLimitExceeded {
+				t.Errorf("Expected code %q, got %q", i18n.CodeRateLimitExceeded, response.Code)
+			}
+			if response.Error != tc.expected {
+				t.Errorf("Expected message %q, got %q", tc.expected, response.Error)
+			}
+		})
+	}
+}
+
+// readLocaleFile reads the messages of a locale file
+func readLocaleFile(t *testing.T, lang string) map[string]map[string]string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("locales", lang+".json"))
+	if err != nil {
+		t.Fatalf("Error reading locale %s: %v", lang, err)
+	}
+	var entries map[string]json.RawMessage
+	if err := json.Unmarshal(data, &entries); err != nil {
+		t.Fatalf("Error parsing locale %s: %v", lang, err)
+	}
+	delete(entries, "_meta")
+
+	messages := make(map[string]map[string]string, len(entries))
+	for key, raw := range entries {
+		var message map[string]string
+		if err := json.Unmarshal(raw, &message); err != nil {
+			t.Fatalf("Error parsing %q of locale %s: %v", key, lang, err)
+		}
+		messages[key] = message
+	}
+	return messages
+}
@@ -0,0 +1,224 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Translation provider names, as used in TRANSLATION_PROVIDERS
+const (
+	ProviderGoogle         = "google"
+	ProviderDeepL          = "deepl"
+	ProviderLibreTranslate = "libretranslate"
+	ProviderMock           = "mock"
+)
+
+// cachedTranslator is implemented by providers that can answer from their cache
+// without calling the remote API
+type cachedTranslator interface {
+	cachedTranslation(text, sourceLang, targetLang string) (string, bool)
+	// cacheStats reports the size and hit/miss statistics of the provider's cache
+	cacheStats() TranslationCacheStats
+}
+
+// translationProvider is a provider of the fallback chain with its circuit breaker
+type translationProvider struct {
+	name    string
+	service TranslationService
+	breaker *CircuitBreaker
+}
+
+// ProviderStatus reports the health of a translation provider
+type ProviderStatus struct {
+	// Provider name
+	// example: deepl
+	Name string `json:"name"`
+	CircuitBreakerStatus
+	// Translation cache of the provider, omitted for providers without a cache
+	Cache *TranslationCacheStats `json:"cache,omitempty"`
+}
+
+// FallbackTranslationService tries translation providers in order, skipping the ones
+// whose circuit breaker is open
+type FallbackTranslationService struct {
+	providers []*translationProvider
+}
+
+// NewFallbackTranslationService creates an empty fallback chain
+func NewFallbackTranslationService() *FallbackTranslationService {
+	return &FallbackTranslationService{}
+}
+
+// AddProvider appends a provider to the chain, protected by its own circuit breaker
+func (s *FallbackTranslationService) AddProvider(name string, service TranslationService, breaker *CircuitBreaker) {
+	s.providers = append(s.providers, &translationProvider{
+		name:    name,
+		service: service,
+		breaker: breaker,
+	})
+}
+
+// Translate implements the TranslationService interface
+func (s *FallbackTranslationService) Translate(ctx context.Context, text, sourceLang, targetLang string) (string, error) {
+	translated, err := s.TranslateBatch(ctx, []string{text}, sourceLang, targetLang)
+	if err != nil {
+		return "", err
+	}
+	return translated[0], nil
+}
+
+// TranslateBatch implements the TranslationService interface. Each provider is tried in
+// order until one succeeds. When every provider fails or is unavailable, the texts are
+// answered from the providers' caches where possible and returned unchanged otherwise.
+func (s *FallbackTranslationService) TranslateBatch(ctx context.Context, texts []string, sourceLang, targetLang string) ([]string, error) {
+	translated, _, err := s.translateBatch(ctx, texts, sourceLang, targetLang)
+	return translated, err
+}
+
+// translateBatch implements TranslateBatch, also returning the name of the provider that
+// translated each text (empty for the texts returned unchanged)
+func (s *FallbackTranslationService) translateBatch(ctx context.Context, texts []string, sourceLang, targetLang string) ([]string, []string, error) {
+	var errs []error
+	for _, provider := range s.providers {
+		if ctx.Err() != nil {
+			break
+		}
+		if !provider.breaker.Allow() {
+			continue
+		}
+
+		translated, err := provider.service.TranslateBatch(ctx, texts, sourceLang, targetLang)
+		if err == nil {
+			provider.breaker.RecordSuccess()
+			providers := make([]string, len(texts))
+			for i := range providers {
+				providers[i] = provider.name
+			}
+			return translated, providers, nil
+		}
+
+		if ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, ErrTranslationBudgetExceeded) {
+			// The client went away, the caller's deadline passed or the budget is spent, which
+			// says nothing about the provider's health. Only a provider-side timeout under a
+			// live context counts as a failure.
+			provider.breaker.RecordCanceled()
+		} else {
+			provider.breaker.RecordFailure(err)
+		}
+		log.Printf("Translation provider %s failed: %v", provider.name, err)
+		errs = append(errs, fmt.Errorf("%s: %w", provider.name, err))
+	}
+
+	if ctx.Err() != nil {
+		errs = append(errs, ctx.Err())
+	}
+	if len(errs) == 0 {
+		errs = append(errs, errors.New("no translation provider available"))
+	}
+	results, providers := s.cachedOrOriginal(texts, sourceLang, targetLang)
+	return results, providers, errors.Join(errs...)
+}
+
+// cachedOrOriginal answers texts from the providers' caches, keeping the original text
+// when no provider has a translation. It also returns the provider whose cache answered
+// each text, empty for the texts kept unchanged.
+func (s *FallbackTranslationService) cachedOrOriginal(texts []string, sourceLang, targetLang string) ([]string, []string) {
+	results := make([]string, len(texts))
+	providers := make([]string, len(texts))
+	for i, text := range texts {
+		results[i] = text
+		if text == "" {
+			continue
+		}
+		for _, provider := range s.providers {
+			cached, ok := provider.service.(cachedTranslator)
+			if !ok {
+				continue
+			}
+			if translated, found := cached.cachedTranslation(text, sourceLang, targetLang); found {
+				results[i] = translated
+				providers[i] = provider.name
+				break
+			}
+		}
+	}
+	return results, providers
+}
+
+// Status reports the health of every provider, in fallback order
+func (s *FallbackTranslationService) Status() []ProviderStatus {
+	statuses := make([]ProviderStatus, 0, len(s.providers))
+	for _, provider := range s.providers {
+		status := ProviderStatus{
+			Name:                 provider.name,
+			CircuitBreakerStatus: provider.breaker.Status(),
+		}
+		if cached, ok := provider.service.(cachedTranslator); ok {
+			if stats := cached.cacheStats(); stats.MaxSize > 0 {
+				status.Cache = &stats
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// translationProvidersFromEnv returns the provider order from TRANSLATION_PROVIDERS,
+// defaulting to every configured provider (Google, then DeepL, then LibreTranslate), or the
+// mock if none is configured
+func translationProvidersFromEnv() []string {
+	if value := os.Getenv("TRANSLATION_PROVIDERS"); value != "" {
+		var names []string
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+				names = append(names, name)
+			}
+		}
+		return names
+	}
+
+	var names []string
+	if GoogleTranslateAPIKey() != "" {
+		names = append(names, ProviderGoogle)
+	}
+	if DeepLAPIKey() != "" {
+		names = append(names, ProviderDeepL)
+	}
+	if LibreTranslateURL() != "" {
+		names = append(names, ProviderLibreTranslate)
+	}
+	if len(names) == 0 {
+		names = append(names, ProviderMock)
+	}
+	return names
+}
+
+// circuitBreakerFromEnv creates a breaker configured by TRANSLATION_BREAKER_THRESHOLD
+// and TRANSLATION_BREAKER_COOLDOWN
+func circuitBreakerFromEnv() *CircuitBreaker {
+	threshold := 5
+	if value := os.Getenv("TRANSLATION_BREAKER_THRESHOLD"); value != "" {
+		if n, err := strconv.Atoi(value); err == nil && n > 0 {
+			threshold = n
+		} else {
+			log.Printf("Invalid value for TRANSLATION_BREAKER_THRESHOLD ignored: %s (using %d as default)", value, threshold)
+		}
+	}
+
+	cooldown := 30 * time.Second
+	if value := os.Getenv("TRANSLATION_BREAKER_COOLDOWN"); value != "" {
+		if d, err := time.ParseDuration(value); err == nil && d > 0 {
+			cooldown = d
+		} else {
+			log.Printf("Invalid value for TRANSLATION_BREAKER_COOLDOWN ignored: %s (using %s as default)", value, cooldown)
+		}
+	}
+
+	return NewCircuitBreaker(threshold, cooldown)
+}
@@ -0,0 +1,130 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// failingTranslationService always fails, counting its calls
+type failingTranslationService struct {
+	calls int
+}
+
+func (s *failingTranslationService) Translate(ctx context.Context, text, sourceLang, targetLang string) (string, error) {
+	s.calls++
+	return "", errors.New("provider unavailable")
+}
+
+func (s *failingTranslationService) TranslateBatch(ctx context.Context, texts []string, sourceLang, targetLang string) ([]string, error) {
+	s.calls++
+	return texts, errors.New("provider unavailable")
+}
+
+// TestCircuitBreaker checks the closed, open and half-open transitions
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	breaker := NewCircuitBreaker(2, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	breaker.RecordFailure(errors.New("timeout"))
+	if !breaker.Allow() {
+		t.Fatal("Circuit should stay closed below the threshold")
+	}
+	breaker.RecordFailure(errors.New("timeout"))
+	if breaker.Allow() {
+		t.Fatal("Circuit should open at the threshold")
+	}
+
+	// After the cooldown only one trial call goes through
+	now = now.Add(time.Minute)
+	if !breaker.Allow() {
+		t.Fatal("Circuit should allow a trial call after the cooldown")
+	}
+	if breaker.Allow() {
+		t.Fatal("Only one trial call should be allowed while half-open")
+	}
+
+	// A failed trial opens the circuit again
+	breaker.RecordFailure(errors.New("timeout"))
+	if status := breaker.Status(); status.State != CircuitOpen || status.LastError != "timeout" {
+		t.Fatalf("Expected an open circuit after a failed trial, got %+v", status)
+	}
+
+	// A successful trial closes it
+	now = now.Add(time.Minute)
+	breaker.Allow()
+	breaker.RecordSuccess()
+	if status := breaker.Status(); status.State != CircuitClosed || status.ConsecutiveFailures != 0 {
+		t.Fatalf("Expected a closed circuit after a successful trial, got %+v", status)
+	}
+}
+
+// TestFallbackTranslationService checks that a failing provider is skipped
+func TestFallbackTranslationService(t *testing.T) {
+	failing := &failingTranslationService{}
+	fallback := NewFallbackTranslationService()
+	fallback.AddProvider("failing", failing, NewCircuitBreaker(1, time.Minute))
+	fallback.AddProvider(ProviderMock, &mockTranslationService{}, NewCircuitBreaker(1, time.Minute))
+
+	for i := 0; i < 3; i++ {
+		translated, err := fallback.Translate(context.Background(), "Hello", "en", "pt-BR")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if translated != "Hello [pt-BR]" {
+			t.Errorf("Expected the fallback translation, got %q", translated)
+		}
+	}
+
+	// The open circuit stops calls to the failing provider after the first failure
+	if failing.calls != 1 {
+		t.Errorf("Expected 1 call to the failing provider, got %d", failing.calls)
+	}
+	if status := fallback.Status(); status[0].State != CircuitOpen || status[1].State != CircuitClosed {
+		t.Errorf("Unexpected provider states: %+v", status)
+	}
+}
+
+// TestFallbackAllProvidersDown checks that the original text is returned when no provider works
+func TestFallbackAllProvidersDown(t *testing.T) {
+	fallback := NewFallbackTranslationService()
+	fallback.AddProvider("failing", &failingTranslationService{}, NewCircuitBreaker(1, time.Minute))
+
+	translated, err := fallback.TranslateBatch(context.Background(), []string{"Hello"}, "en", "pt-BR")
+	if err == nil {
+		t.Error("Expected an error when every provider fails")
+	}
+	if translated[0] != "Hello" {
+		t.Errorf("Expected the original text, got %q", translated[0])
+	}
+}
+
+// slowTranslationService answers only once the caller's context is done
+type slowTranslationService struct{}
+
+func (slowTranslationService) Translate(ctx context.Context, text, sourceLang, targetLang string) (string, error) {
+	<-ctx.Done()
+	return "", ctx.Err()
+}
+
+func (slowTranslationService) TranslateBatch(ctx context.Context, texts []string, sourceLang, targetLang string) ([]string, error) {
+	<-ctx.Done()
+	return texts, ctx.Err()
+}
+
+// TestFallbackCallerDeadline checks that the caller's own deadline doesn't open the circuit
+func TestFallbackCallerDeadline(t *testing.T) {
+	fallback := NewFallbackTranslationService()
+	fallback.AddProvider("slow", slowTranslationService{}, NewCircuitBreaker(1, time.Minute))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := fallback.TranslateBatch(ctx, []string{"Hello"}, "en", "pt-BR"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the deadline error, got %v", err)
+	}
+	if status := fallback.Status(); status[0].State != CircuitClosed || status[0].ConsecutiveFailures != 0 {
+		t.Errorf("Expected the caller's deadline not to count as a failure, got %+v", status[0])
+	}
+}
@@ -0,0 +1,107 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// Text directions of the supported languages
+const (
+	DirLTR = "ltr"
+	DirRTL = "rtl"
+)
+
+//go:generate go run gen_date_formats.go
+
+// dateFormat is the CLDR long date format of a language in the Gregorian calendar
+type dateFormat struct {
+	// CLDR pattern: d is the day, M the month number, MMMM the month name, y the year,
+	// G the era, and text between single quotes is literal
+	pattern string
+	// Month names as used inside a date (some languages decline them, e.g. Russian "июня")
+	months [12]string
+	// Abbreviated name of the current era (e.g. "AD")
+	era string
+}
+
+// lookupDateFormat returns the long date format of a language, inherited from its CLDR
+// parent locales when the language has none of its own (e.g. pt-BR from pt). It reports
+// false when CLDR has no format for the language, or one FormatDate can't render.
+func lookupDateFormat(lang string) (dateFormat, bool) {
+	for tag := language.Make(lang); tag != language.Und; tag = tag.Parent() {
+		if format, ok := longDateFormats[tag.String()]; ok {
+			return format, format.pattern != ""
+		}
+	}
+	return dateFormat{}, false
+}
+
+// FormatDate formats a YYYY-MM-DD date in the long format of the language, with the
+// language's digits (e.g. "10 de junho de 2025", "2025年6月10日", "١٠ يونيو ٢٠٢٥").
+// Languages without a CLDR date format use the English format.
+func FormatDate(date, lang string) (string, error) {
+	parsed, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return "", fmt.Errorf("invalid date %q: %w", date, err)
+	}
+	format, ok := lookupDateFormat(lang)
+	if !ok {
+		lang, format = "en", longDateFormats["en"]
+	}
+	printer := message.NewPrinter(language.Make(lang))
+	digits := func(n, minDigits int) string {
+		return printer.Sprint(number.Decimal(n, number.NoSeparator(), number.MinIntegerDigits(minDigits)))
+	}
+
+	var formatted strings.Builder
+	pattern := []rune(format.pattern)
+	for i := 0; i < len(pattern); {
+		switch r := pattern[i]; {
+		case r == '\'' && i+1 < len(pattern) && pattern[i+1] == '\'':
+			// Escaped single quote
+			formatted.WriteRune(r)
+			i += 2
+		case r == '\'':
+			// Quoted literal text
+			end := i + 1
+			for end < len(pattern) && pattern[end] != '\'' {
+				end++
+			}
+			formatted.WriteString(string(pattern[i+1 : end]))
+			i = end + 1
+		case r == 'd' || r == 'M' || r == 'y' || r == 'G':
+			count := 1
+			for i+count < len(pattern) && pattern[i+count] == r {
+				count++
+			}
+			switch {
+			case r == 'd':
+				formatted.WriteString(digits(parsed.Day(), count))
+			case r == 'y':
+				formatted.WriteString(digits(parsed.Year(), 1))
+			case r == 'G':
+				formatted.WriteString(format.era)
+			case count >= 3:
+				formatted.WriteString(format.months[parsed.Month()-1])
+			default:
+				formatted.WriteString(digits(int(parsed.Month()), count))
+			}
+			i += count
+		default:
+			formatted.WriteRune(r)
+			i++
+		}
+	}
+	return formatted.String(), nil
+}
+
+// FormatNumber formats an integer with the digits and digit grouping of the language
+// (e.g. "1,234" in English, "1.234" in German, "١٬٢٣٤" in Arabic)
+func FormatNumber(n int, lang string) string {
+	return message.NewPrinter(language.Make(lang)).Sprint(number.Decimal(n))
+}
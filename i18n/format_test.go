@@ -0,0 +1,75 @@
+package i18n
+
+import "testing"
+
+// TestFormatDate checks the CLDR long date formats and the digits of each language
+func TestFormatDate(t *testing.T) {
+	tests := map[string]string{
+		"en":    "June 10, 2025",
+		"es":    "10 de junio de 2025",
+		"pt-BR": "10 de junho de 2025",
+		"de":    "10. Juni 2025",
+		"ru":    "10 июня 2025 г.",
+		"hu":    "2025. június 10.",
+		"ja":    "2025年6月10日",
+		"ko":    "2025년 6월 10일",
+		"ar":    "١٠ يونيو ٢٠٢٥",
+		"fa":    "۱۰ ژوئن ۲۰۲۵",
+		"en-AU": "10 June 2025",
+		"th":    "10 มิถุนายน ค.ศ. 2025",
+		"xx":    "June 10, 2025",
+		"mn":    "June 10, 2025",
+	}
+	for lang, expected := range tests {
+		formatted, err := FormatDate("2025-06-10", lang)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if formatted != expected {
+			t.Errorf("FormatDate(%s) = %q; expected %q", lang, formatted, expected)
+		}
+	}
+
+	if _, err := FormatDate("10/06/2025", "en"); err == nil {
+		t.Error("Expected an error for an invalid date")
+	}
+}
+
+// TestLookupDateFormat checks that every supported language has a date format, inherited
+// from its CLDR parent when needed, and that languages CLDR can't format are reported
+func TestLookupDateFormat(t *testing.T) {
+	for _, lang := range SupportedLanguages() {
+		if _, ok := lookupDateFormat(lang); !ok {
+			t.Errorf("No date format for %s", lang)
+		}
+	}
+	if format, ok := lookupDateFormat("pt-BR"); !ok || format.months[5] != "junho" {
+		t.Errorf("Expected pt-BR to inherit the pt format, got %+v", format)
+	}
+	for _, lang := range []string{"xx", "tlh", "mn"} {
+		if _, ok := lookupDateFormat(lang); ok {
+			t.Errorf("Expected no date format for %s", lang)
+		}
+	}
+
+	// Two-digit days are padded
+	if formatted, _ := FormatDate("2025-06-05", "af"); formatted != "05 Junie 2025" {
+		t.Errorf("FormatDate(af) = %q; expected %q", formatted, "05 Junie 2025")
+	}
+}
+
+// TestFormatNumberAndDirection checks the localized digits and the text direction
+func TestFormatNumberAndDirection(t *testing.T) {
+	tests := map[string]string{"en": "1,234", "de": "1.234", "fr": "1\u00a0234", "ar": "١٬٢٣٤"}
+	for lang, expected := range tests {
+		if formatted := FormatNumber(1234, lang); formatted != expected {
+			t.Errorf("FormatNumber(%s) = %q; expected %q", lang, formatted, expected)
+		}
+	}
+
+	for lang, expected := range map[string]string{"ar": DirRTL, "fa": DirRTL, "en": DirLTR, "ja": DirLTR} {
+		if dir := TextDirection(lang); dir != expected {
+			t.Errorf("TextDirection(%s) = %q; expected %q", lang, dir, expected)
+		}
+	}
+}
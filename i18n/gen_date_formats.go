@@ -0,0 +1,285 @@
+//go:build ignore
+
+// gen_date_formats generates date_formats.go, the CLDR long date formats used by
+// FormatDate, from the CLDR data shipped with the golang.org/x/text version of go.mod.
+// That data is only reachable through internal packages of golang.org/x/text, so the
+// generator copies the module to a temporary directory and runs an export test inside
+// its date package.
+//
+// Run it with "go generate ./i18n".
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// exportTest prints, for every locale of the CLDR tree, its parent and its long Gregorian
+// date pattern, wide month names and abbreviated era as JSON lines
+const exportTest = `package date
+
+import (
+	"encoding/json"
+	"os"
+	"strconv"
+	"testing"
+
+	"golang.org/x/text/internal/language/compact"
+)
+
+func TestExportDateFormats(t *testing.T) {
+	output, err := os.Create(os.Getenv("DATE_FORMATS_OUTPUT"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer output.Close()
+
+	path := func(keys ...string) []uint16 {
+		p := make([]uint16, len(keys))
+		for i, key := range keys {
+			if n, err := strconv.Atoi(key); err == nil {
+				p[i] = uint16(n)
+			} else {
+				p[i] = enumMap[key]
+			}
+		}
+		return p
+	}
+	encoder := json.NewEncoder(output)
+	for i := 0; i < compact.NumCompactTags; i++ {
+		id := compact.ID(i)
+		locale := map[string]any{
+			"tag":     id.Tag().String(),
+			"parent":  id.Parent().Tag().String(),
+			"pattern": tree.Lookup(id, path("calendars", "gregorian", "dateFormats", "long", "")...),
+			"era":     tree.Lookup(id, path("calendars", "gregorian", "eras", "widthAbbreviated", "", "1")...),
+			"cldr":    CLDRVersion,
+		}
+		var months []string
+		for month := 1; month <= 12; month++ {
+			months = append(months, tree.Lookup(id, path("calendars", "gregorian", "months", "format", "widthWide", strconv.Itoa(month))...))
+		}
+		locale["months"] = months
+		if err := encoder.Encode(locale); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+`
+
+// locale is one line of the export
+type locale struct {
+	Tag     string   `json:"tag"`
+	Parent  string   `json:"parent"`
+	Pattern string   `json:"pattern"`
+	Months  []string `json:"months"`
+	Era     string   `json:"era"`
+	CLDR    string   `json:"cldr"`
+}
+
+// entry is the part of a locale FormatDate uses. An empty pattern means the CLDR pattern
+// has fields FormatDate doesn't render.
+type entry struct {
+	pattern string
+	months  string // Month names joined by newlines, when the pattern names the month
+	era     string // Abbreviated era, when the pattern has one
+}
+
+func main() {
+	dir, version := textModule()
+	work, err := os.MkdirTemp("", "gen_date_formats")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.RemoveAll(work)
+
+	if err := copyModule(dir, work); err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(work, "date", "export_date_formats_test.go"), []byte(exportTest), 0644); err != nil {
+		log.Fatal(err)
+	}
+	exported := filepath.Join(work, "date_formats.json")
+	cmd := exec.Command("go", "test", "-count=1", "-run", "^TestExportDateFormats$", "./date")
+	cmd.Dir = work
+	cmd.Env = append(os.Environ(), "DATE_FORMATS_OUTPUT="+exported, "GOWORK=off")
+	cmd.Stderr = os.Stderr
+	if out, err := cmd.Output(); err != nil {
+		log.Fatalf("export failed: %v\n%s", err, out)
+	}
+
+	locales, err := readLocales(exported)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("date_formats.go", generate(locales, version), 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// textModule returns the directory and version of the golang.org/x/text module of go.mod
+func textModule() (dir, version string) {
+	out, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}} {{.Version}}", "golang.org/x/text").Output()
+	if err != nil {
+		log.Fatalf("golang.org/x/text not found, run go mod download first: %v", err)
+	}
+	fields := strings.Fields(string(out))
+	if len(fields) != 2 {
+		log.Fatalf("unexpected go list output: %q", out)
+	}
+	return fields[0], fields[1]
+}
+
+// copyModule copies the read-only module cache directory src to dst
+func copyModule(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return os.MkdirAll(filepath.Join(dst, rel), 0755)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(dst, rel), data, 0644)
+	})
+}
+
+// readLocales reads the JSON lines of the export
+func readLocales(path string) ([]locale, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var locales []locale
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var l locale
+		if err := json.Unmarshal(scanner.Bytes(), &l); err != nil {
+			return nil, err
+		}
+		locales = append(locales, l)
+	}
+	return locales, scanner.Err()
+}
+
+// fields returns the pattern letters outside quoted literals, each run counted once
+// (e.g. "d 'de' MMMM" gives ["d", "MMMM"])
+func fields(pattern string) []string {
+	var result []string
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == '\'':
+			i++
+			for i < len(runes) && runes[i] != '\'' {
+				i++
+			}
+			i++
+		case (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z'):
+			count := 1
+			for i+count < len(runes) && runes[i+count] == r {
+				count++
+			}
+			result = append(result, strings.Repeat(string(r), count))
+			i += count
+		default:
+			i++
+		}
+	}
+	return result
+}
+
+// supportedFields are the pattern fields FormatDate renders
+var supportedFields = map[string]bool{"d": true, "dd": true, "M": true, "MM": true, "MMMM": true, "y": true, "G": true}
+
+// newEntry keeps the data of a locale its pattern needs
+func newEntry(l locale) entry {
+	e := entry{pattern: l.Pattern}
+	for _, field := range fields(l.Pattern) {
+		switch {
+		case !supportedFields[field]:
+			return entry{}
+		case field == "MMMM":
+			e.months = strings.Join(l.Months, "\n")
+		case field == "G":
+			e.era = l.Era
+		}
+	}
+	return e
+}
+
+// generate writes the table of the locales whose format differs from their parent's.
+// Locales with the format of the root locale have no format of their own in CLDR and are
+// left out.
+func generate(locales []locale, version string) []byte {
+	entries := make(map[string]entry, len(locales))
+	parents := make(map[string]string, len(locales))
+	for _, l := range locales {
+		entries[l.Tag] = newEntry(l)
+		parents[l.Tag] = l.Parent
+	}
+
+	var tags []string
+	for tag, e := range entries {
+		if tag != "und" && e != entries[parents[tag]] {
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by gen_date_formats.go from the CLDR %s data of golang.org/x/text %s. DO NOT EDIT.\n\n", locales[0].CLDR, version)
+	b.WriteString("package i18n\n\n")
+	b.WriteString("// longDateFormats are the CLDR long date formats in the Gregorian calendar, by language\n")
+	b.WriteString("// tag. A tag with the same format as its parent is left out, and an empty pattern means\n")
+	b.WriteString("// the CLDR format has fields FormatDate doesn't render.\n")
+	b.WriteString("var longDateFormats = map[string]dateFormat{\n")
+	for _, tag := range tags {
+		e := entries[tag]
+		fmt.Fprintf(&b, "%q: {", tag)
+		var parts []string
+		if e.pattern != "" {
+			parts = append(parts, fmt.Sprintf("pattern: %q", e.pattern))
+		}
+		if e.months != "" {
+			var months []string
+			for _, month := range strings.Split(e.months, "\n") {
+				months = append(months, fmt.Sprintf("%q", month))
+			}
+			parts = append(parts, fmt.Sprintf("months: [12]string{%s}", strings.Join(months, ", ")))
+		}
+		if e.era != "" {
+			parts = append(parts, fmt.Sprintf("era: %q", e.era))
+		}
+		b.WriteString(strings.Join(parts, ", "))
+		b.WriteString("},\n")
+	}
+	b.WriteString("}\n")
+
+	formatted, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatalf("generated code: %v", err)
+	}
+	return formatted
+}
@@ -0,0 +1,405 @@
+package i18n
+
+import (
+	"astrovista-api/database"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrGlossaryEntryNotFound is returned when deleting an unknown glossary entry
+var ErrGlossaryEntryNotFound = errors.New("glossary entry not found")
+
+// GlossaryEntry is a term with a fixed translation. Entries without a language are
+// protected terms, kept verbatim in every language.
+type GlossaryEntry struct {
+	ID primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	// English term, matched case-sensitively on word boundaries
+	// example: redshift
+	Term string `bson:"term" json:"term"`
+	// Target language; empty for a do-not-translate term
+	// example: pt-BR
+	Lang string `bson:"lang,omitempty" json:"lang,omitempty"`
+	// Fixed translation; empty keeps the term as-is
+	// example: desvio para o vermelho
+	Translation string    `bson:"translation,omitempty" json:"translation,omitempty"`
+	CreatedAt   time.Time `bson:"created_at" json:"created_at"`
+}
+
+// Validate checks that an entry can be used by every provider
+func (e GlossaryEntry) Validate() error {
+	if strings.TrimSpace(e.Term) == "" {
+		return errors.New("the term is required")
+	}
+	if strings.ContainsAny(e.Term+e.Translation, "\t\r\n") {
+		return errors.New("terms and translations cannot contain tabs or line breaks")
+	}
+	if e.Lang == "" && e.Translation != "" {
+		return errors.New("a translation requires a language")
+	}
+	if e.Lang != "" && (e.Lang == "en" || !IsSupportedLanguage(e.Lang)) {
+		return fmt.Errorf("unsupported language %q", e.Lang)
+	}
+	return nil
+}
+
+// Glossary is an immutable snapshot of the glossary entries
+type Glossary struct {
+	entries  []GlossaryEntry
+	version  string
+	patterns sync.Map // Target language -> *glossaryPattern
+}
+
+// glossaryPattern matches the terms that apply to one target language
+type glossaryPattern struct {
+	regexp       *regexp.Regexp
+	replacements map[string]string // Term -> text in the target language
+}
+
+// placeholderPattern matches the placeholders that replace terms, tolerating spaces added by providers
+var placeholderPattern = regexp.MustCompile(`⟦\s*(\d+)\s*⟧`)
+
+var (
+	// currentGlossary holds the glossary applied to translations
+	currentGlossary atomic.Pointer[Glossary]
+	// emptyGlossary is used until a glossary is loaded
+	emptyGlossary = NewGlossary(nil)
+)
+
+// NewGlossary creates a glossary snapshot from its entries
+func NewGlossary(entries []GlossaryEntry) *Glossary {
+	sorted := make([]GlossaryEntry, len(entries))
+	copy(sorted, entries)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Term != sorted[j].Term {
+			return sorted[i].Term < sorted[j].Term
+		}
+		return sorted[i].Lang < sorted[j].Lang
+	})
+
+	glossary := &Glossary{entries: sorted}
+	if len(sorted) > 0 {
+		hash := sha256.New()
+		for _, entry := range sorted {
+			fmt.Fprintf(hash, "%s\t%s\t%s\n", entry.Term, entry.Lang, entry.Translation)
+		}
+		glossary.version = hex.EncodeToString(hash.Sum(nil))[:12]
+	}
+	return glossary
+}
+
+// CurrentGlossary returns the glossary applied to translations (never nil)
+func CurrentGlossary() *Glossary {
+	if glossary := currentGlossary.Load(); glossary != nil {
+		return glossary
+	}
+	return emptyGlossary
+}
+
+// SetGlossary replaces the glossary applied to translations
+func SetGlossary(glossary *Glossary) {
+	currentGlossary.Store(glossary)
+}
+
+// Version identifies the glossary content; empty when the glossary is empty
+func (g *Glossary) Version() string {
+	return g.version
+}
+
+// Terms returns the text each term must have in the target language
+func (g *Glossary) Terms(targetLang string) map[string]string {
+	return g.pattern(targetLang).replacements
+}
+
+// Applies reports whether any term applies when translating from the source language to
+// the target language. Terms are English, so the glossary only applies to English texts.
+func (g *Glossary) Applies(sourceLang, targetLang string) bool {
+	return sourceLang == "en" && len(g.Terms(targetLang)) > 0
+}
+
+// pattern builds (once per language) the regular expression matching the terms
+func (g *Glossary) pattern(targetLang string) *glossaryPattern {
+	if cached, ok := g.patterns.Load(targetLang); ok {
+		return cached.(*glossaryPattern)
+	}
+
+	replacements := make(map[string]string)
+	for _, entry := range g.entries {
+		if entry.Lang == "" {
+			if _, exists := replacements[entry.Term]; !exists {
+				replacements[entry.Term] = entry.Term
+			}
+		} else if entry.Lang == targetLang {
+			// Language-specific translations win over the do-not-translate list
+			translation := entry.Translation
+			if translation == "" {
+				translation = entry.Term
+			}
+			replacements[entry.Term] = translation
+		}
+	}
+
+	pattern := &glossaryPattern{replacements: replacements}
+	if len(replacements) > 0 {
+		terms := make([]string, 0, len(replacements))
+		for term := range replacements {
+			terms = append(terms, term)
+		}
+		// Longest first, so "Messier 31" wins over "Messier"
+		sort.Slice(terms, func(i, j int) bool { return len(terms[i]) > len(terms[j]) })
+
+		alternatives := make([]string, len(terms))
+		for i, term := range terms {
+			alternatives[i] = wordBoundary(term, true) + regexp.QuoteMeta(term) + wordBoundary(term, false)
+		}
+		pattern.regexp = regexp.MustCompile(strings.Join(alternatives, "|"))
+	}
+
+	actual, _ := g.patterns.LoadOrStore(targetLang, pattern)
+	return actual.(*glossaryPattern)
+}
+
+// wordBoundary returns \b when the term starts (or ends) with a word character
+func wordBoundary(term string, start bool) string {
+	var r rune
+	if start {
+		r, _ = utf8.DecodeRuneInString(term)
+	} else {
+		r, _ = utf8.DecodeLastRuneInString(term)
+	}
+	if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+		return `\b`
+	}
+	return ""
+}
+
+// Mask replaces the glossary terms in a text with numbered placeholders, returning the
+// masked text and the target-language text of each placeholder
+func (g *Glossary) Mask(text, targetLang string) (string, []string) {
+	pattern := g.pattern(targetLang)
+	if pattern.regexp == nil {
+		return text, nil
+	}
+
+	var replacements []string
+	masked := pattern.regexp.ReplaceAllStringFunc(text, func(term string) string {
+		replacements = append(replacements, pattern.replacements[term])
+		return "⟦" + strconv.Itoa(len(replacements)-1) + "⟧"
+	})
+	return masked, replacements
+}
+
+// Unmask restores the placeholders of a translated text
+func Unmask(text string, replacements []string) string {
+	if len(replacements) == 0 {
+		return text
+	}
+	return placeholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		index, err := strconv.Atoi(placeholderPattern.FindStringSubmatch(placeholder)[1])
+		if err != nil || index >= len(replacements) {
+			return placeholder
+		}
+		return replacements[index]
+	})
+}
+
+// translateMasked translates texts with the glossary terms masked, so the provider can't alter them
+func translateMasked(ctx context.Context, glossary *Glossary, texts []string, targetLang string, translate func(ctx context.Context, texts []string) ([]string, error)) ([]string, error) {
+	masked := make([]string, len(texts))
+	replacements := make([][]string, len(texts))
+	for i, text := range texts {
+		masked[i], replacements[i] = glossary.Mask(text, targetLang)
+	}
+
+	translated, err := translate(ctx, masked)
+	if translated == nil {
+		return nil, err
+	}
+	results := make([]string, len(texts))
+	for i := range texts {
+		if err != nil && translated[i] == masked[i] {
+			// Left untranslated: return the original text, not the masked one
+			results[i] = texts[i]
+			continue
+		}
+		results[i] = Unmask(translated[i], replacements[i])
+	}
+	return results, err
+}
+
+// glossaryTranslationService applies the glossary to a provider without glossary support
+type glossaryTranslationService struct {
+	service TranslationService
+}
+
+// withGlossary wraps a provider so the glossary is applied to it. DeepL applies the
+// glossary itself through its glossary API.
+func withGlossary(service TranslationService) TranslationService {
+	if _, native := service.(*DeepLClient); native {
+		return service
+	}
+	return &glossaryTranslationService{service: service}
+}
+
+// Translate implements the TranslationService interface
+func (s *glossaryTranslationService) Translate(ctx context.Context, text, sourceLang, targetLang string) (string, error) {
+	translated, err := s.TranslateBatch(ctx, []string{text}, sourceLang, targetLang)
+	if err != nil {
+		return "", err
+	}
+	return translated[0], nil
+}
+
+// TranslateBatch implements the TranslationService interface, masking the glossary terms
+func (s *glossaryTranslationService) TranslateBatch(ctx context.Context, texts []string, sourceLang, targetLang string) ([]string, error) {
+	glossary := CurrentGlossary()
+	if !glossary.Applies(sourceLang, targetLang) {
+		return s.service.TranslateBatch(ctx, texts, sourceLang, targetLang)
+	}
+	return translateMasked(ctx, glossary, texts, targetLang, func(ctx context.Context, masked []string) ([]string, error) {
+		return s.service.TranslateBatch(ctx, masked, sourceLang, targetLang)
+	})
+}
+
+// cachedTranslation looks up the masked text in the wrapped provider's cache
+func (s *glossaryTranslationService) cachedTranslation(text, sourceLang, targetLang string) (string, bool) {
+	cached, ok := s.service.(cachedTranslator)
+	if !ok {
+		return "", false
+	}
+	glossary := CurrentGlossary()
+	if !glossary.Applies(sourceLang, targetLang) {
+		return cached.cachedTranslation(text, sourceLang, targetLang)
+	}
+	masked, replacements := glossary.Mask(text, targetLang)
+	translated, found := cached.cachedTranslation(masked, sourceLang, targetLang)
+	if !found {
+		return "", false
+	}
+	return Unmask(translated, replacements), true
+}
+
+// cacheStats reports the statistics of the wrapped provider's cache, empty when it has none
+func (s *glossaryTranslationService) cacheStats() TranslationCacheStats {
+	if cached, ok := s.service.(cachedTranslator); ok {
+		return cached.cacheStats()
+	}
+	return TranslationCacheStats{}
+}
+
+// EnsureGlossaryIndexes creates the unique index of the glossary
+func EnsureGlossaryIndexes(ctx context.Context) error {
+	if database.GlossaryCollection == nil {
+		return nil
+	}
+	_, err := database.GlossaryCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "term", Value: 1}, {Key: "lang", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+// ListGlossaryEntries returns every glossary entry
+func ListGlossaryEntries(ctx context.Context) ([]GlossaryEntry, error) {
+	cursor, err := database.GlossaryCollection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "term", Value: 1}, {Key: "lang", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	entries := []GlossaryEntry{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// AddGlossaryEntry stores a glossary entry and reloads the glossary
+func AddGlossaryEntry(ctx context.Context, entry GlossaryEntry) (*GlossaryEntry, error) {
+	entry.Term = strings.TrimSpace(entry.Term)
+	entry.Translation = strings.TrimSpace(entry.Translation)
+	if err := entry.Validate(); err != nil {
+		return nil, err
+	}
+	entry.ID = primitive.NilObjectID
+	entry.CreatedAt = time.Now().UTC()
+
+	result, err := database.GlossaryCollection.InsertOne(ctx, entry)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, fmt.Errorf("the term %q already has an entry for this language", entry.Term)
+	} else if err != nil {
+		return nil, err
+	}
+	entry.ID = result.InsertedID.(primitive.ObjectID)
+
+	if err := LoadGlossary(ctx); err != nil {
+		log.Printf("Error reloading glossary: %v", err)
+	}
+	return &entry, nil
+}
+
+// DeleteGlossaryEntry removes a glossary entry and reloads the glossary
+func DeleteGlossaryEntry(ctx context.Context, id primitive.ObjectID) error {
+	result, err := database.GlossaryCollection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrGlossaryEntryNotFound
+	}
+
+	if err := LoadGlossary(ctx); err != nil {
+		log.Printf("Error reloading glossary: %v", err)
+	}
+	return nil
+}
+
+// LoadGlossary reads the glossary from MongoDB and applies it to new translations
+func LoadGlossary(ctx context.Context) error {
+	if database.GlossaryCollection == nil {
+		return nil
+	}
+	entries, err := ListGlossaryEntries(ctx)
+	if err != nil {
+		return err
+	}
+	glossary := NewGlossary(entries)
+	if glossary.Version() != CurrentGlossary().Version() {
+		log.Printf("Glossary loaded: %d entries (version %s)", len(entries), glossary.Version())
+	}
+	SetGlossary(glossary)
+	return nil
+}
+
+// StartGlossaryRefresh reloads the glossary periodically, so changes made on other
+// replicas are picked up
+func StartGlossaryRefresh(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			if err := LoadGlossary(ctx); err != nil {
+				log.Printf("Error refreshing glossary: %v", err)
+			}
+			cancel()
+		}
+	}()
+}
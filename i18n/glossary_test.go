@@ -0,0 +1,66 @@
+package i18n
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// TestGlossaryMask checks that terms are masked and restored in the target language
+func TestGlossaryMask(t *testing.T) {
+	glossary := NewGlossary([]GlossaryEntry{
+		{Term: "Hubble"},
+		{Term: "Messier 31"},
+		{Term: "Messier"},
+		{Term: "redshift", Lang: "pt-BR", Translation: "desvio para o vermelho"},
+	})
+
+	text := "Hubble measured the redshift of Messier 31, not of Hubbleville."
+	masked, replacements := glossary.Mask(text, "pt-BR")
+	if strings.Contains(masked, "Hubble measured") || strings.Contains(masked, "Messier") || strings.Contains(masked, "redshift") {
+		t.Fatalf("Terms were not masked: %q", masked)
+	}
+	if !strings.Contains(masked, "Hubbleville") {
+		t.Errorf("Terms should only match whole words: %q", masked)
+	}
+
+	// Simulate a provider that adds spaces inside the placeholders
+	translated := strings.NewReplacer("⟦", "⟦ ", "⟧", " ⟧").Replace(masked)
+	restored := Unmask(translated, replacements)
+	expected := "Hubble measured the desvio para o vermelho of Messier 31, not of Hubbleville."
+	if restored != expected {
+		t.Errorf("Expected %q, got %q", expected, restored)
+	}
+
+	// Language-specific entries don't apply to other languages
+	if _, replacements := glossary.Mask("the redshift", "es"); len(replacements) != 0 {
+		t.Errorf("The pt-BR entry should not apply to es, got %v", replacements)
+	}
+}
+
+// TestGlossaryTranslationService checks that wrapped providers never see the protected terms
+func TestGlossaryTranslationService(t *testing.T) {
+	SetGlossary(NewGlossary([]GlossaryEntry{{Term: "JWST"}}))
+	defer SetGlossary(NewGlossary(nil))
+
+	service := withGlossary(&mockTranslationService{})
+	translated, err := service.Translate(context.Background(), "JWST image", "en", "pt-BR")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if translated != "JWST image [pt-BR]" {
+		t.Errorf("Expected the protected term to be restored, got %q", translated)
+	}
+}
+
+// TestGlossaryVersion checks that the version only depends on the entries
+func TestGlossaryVersion(t *testing.T) {
+	a := NewGlossary([]GlossaryEntry{{Term: "Hubble"}, {Term: "JWST"}})
+	b := NewGlossary([]GlossaryEntry{{Term: "JWST"}, {Term: "Hubble"}})
+	if a.Version() == "" || a.Version() != b.Version() {
+		t.Errorf("Expected equal versions, got %q and %q", a.Version(), b.Version())
+	}
+	if NewGlossary(nil).Version() != "" {
+		t.Error("An empty glossary should have no version")
+	}
+}
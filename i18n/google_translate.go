@@ -3,6 +3,7 @@ package i18n
 import (
 	"astrovista-api/cache"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -57,72 +58,100 @@ func NewGoogleTranslateClient(apiKey string) *GoogleTranslateClient { // Use Red
 	}
 }
 
+// googleBatchLimits are the Google Translate v2 limits per request
+var googleBatchLimits = batchLimits{
+	maxItems: 128,   // Maximum number of "q" segments
+	maxChars: 30000, // Recommended maximum request size in characters
+}
+
 // Translate implements the TranslationService interface for Google Translate
-func (c *GoogleTranslateClient) Translate(text, sourceLang, targetLang string) (string, error) {
-	// Check the cache first
-	cacheKey := fmt.Sprintf("%s:%s:%s", sourceLang, targetLang, getHashKey(text))
-	if cachedText, found := c.cache.Get(cacheKey); found {
-		return cachedText, nil
+func (c *GoogleTranslateClient) Translate(ctx context.Context, text, sourceLang, targetLang string) (string, error) {
+	translated, err := c.TranslateBatch(ctx, []string{text}, sourceLang, targetLang)
+	if err != nil {
+		return "", err
 	}
+	return translated[0], nil
+}
+
+// TranslateBatch implements the TranslationService interface for Google Translate,
+// sending up to 128 texts per request
+func (c *GoogleTranslateClient) TranslateBatch(ctx context.Context, texts []string, sourceLang, targetLang string) ([]string, error) {
+	return batchTranslate(ctx, c.cache, ProviderGoogle, texts, sourceLang, targetLang, googleBatchLimits, func(ctx context.Context, chunk []string, format textFormat) ([]string, error) {
+		return c.translateChunk(ctx, chunk, format, sourceLang, targetLang)
+	})
+}
+
+// cachedTranslation returns a translation from the cache without calling the API
+func (c *GoogleTranslateClient) cachedTranslation(text, sourceLang, targetLang string) (string, bool) {
+	return c.cache.Get(translationCacheKey(ProviderGoogle, sourceLang, targetLang, cacheGlossaryVersion(sourceLang, targetLang), text))
+}
 
+// cacheStats reports the statistics of the translation cache
+func (c *GoogleTranslateClient) cacheStats() TranslationCacheStats {
+	return c.cache.Stats()
+}
+
+// translateChunk sends one request to the Google Translate API
+func (c *GoogleTranslateClient) translateChunk(ctx context.Context, texts []string, format textFormat, sourceLang, targetLang string) ([]string, error) {
 	// Sanitize languages to the format expected by Google
-	sourceLang = sanitizeLanguageCode(sourceLang)
-	targetLang = sanitizeLanguageCode(targetLang)
+	sourceLang = providerLanguageCode(ProviderGoogle, sourceLang, sanitizeLanguageCode)
+	targetLang = providerLanguageCode(ProviderGoogle, targetLang, sanitizeLanguageCode)
 
 	// Prepare the request
 	reqBody := GoogleTranslateRequest{
-		Q:      []string{text},
+		Q:      texts,
 		Source: sourceLang,
 		Target: targetLang,
-		Format: "text", // or "html" if the text contains HTML
+		Format: string(format), // "html" keeps the tags and links of texts with markup
 	}
 
 	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
-		return "", fmt.Errorf("error serializing request: %v", err)
+		return nil, fmt.Errorf("error serializing request: %v", err)
 	}
 
-	// API URL with API key
-	url := fmt.Sprintf("https://translation.googleapis.com/language/translate/v2?key=%s", c.apiKey)
+	// API URL (the key goes in a header so it never shows up in logged errors)
+	url := "https://translation.googleapis.com/language/translate/v2"
 
 	// Create the HTTP request
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return "", fmt.Errorf("error creating request: %v", err)
+		return nil, fmt.Errorf("error creating request: %v", err)
 	}
 
 	// Set the headers
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Goog-Api-Key", c.apiKey)
 	// Execute the request
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("error executing request: %v", err)
+		return nil, fmt.Errorf("error executing request: %v", err)
 	}
 	defer resp.Body.Close()
 
 	// Check the response status
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("API returned non-OK status: %d", resp.StatusCode)
+		return nil, fmt.Errorf("API returned non-OK status: %d", resp.StatusCode)
 	}
 
 	// Decode the response
 	var translateResp GoogleTranslateResponse
 	if err := json.NewDecoder(resp.Body).Decode(&translateResp); err != nil {
-		return "", fmt.Errorf("error decoding response: %v", err)
+		return nil, fmt.Errorf("error decoding response: %v", err)
 	}
 
 	// Check if there are translations
 	if len(translateResp.Data.Translations) == 0 {
-		return "", fmt.Errorf("no translation returned")
+		return nil, fmt.Errorf("no translation returned")
 	}
 
-	// Get the translated text
-	translatedText := translateResp.Data.Translations[0].TranslatedText
-
-	// Store in cache
-	c.cache.Set(cacheKey, translatedText)
+	// Get the translated texts, in the same order as the request
+	translated := make([]string, 0, len(translateResp.Data.Translations))
+	for _, translation := range translateResp.Data.Translations {
+		translated = append(translated, translation.TranslatedText)
+	}
 
-	return translatedText, nil
+	return translated, nil
 }
 
 // Sanitize the language code to the format accepted by Google Translate
@@ -132,15 +161,6 @@ func sanitizeLanguageCode(lang string) string {
 	return strings.ToLower(parts[0])
 }
 
-// getHashKey creates a simplified hash key for long texts
-func getHashKey(text string) string {
-	if len(text) <= 32 {
-		return text
-	}
-	// A simple implementation for long texts
-	return fmt.Sprintf("%s...%s:%d", text[:16], text[len(text)-16:], len(text))
-}
-
 // GoogleTranslateAPIKey returns the Google Translate API key from the environment
 func GoogleTranslateAPIKey() string {
 	return os.Getenv("GOOGLE_TRANSLATE_API_KEY")
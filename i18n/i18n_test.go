@@ -1,7 +1,8 @@
-package i18n
+package i18n_test
 
 import (
 	"astrovista-api/i18n"
+	"context"
 	"os"
 	"testing"
 )
@@ -28,7 +29,7 @@ func TestTranslationService(t *testing.T) {
 		i18n.InitTranslationService()
 
 		text := "Hello, world!"
-		translated, err := i18n.TranslateText(text, "pt-BR")
+		translated, err := i18n.TranslateText(context.Background(), text, "pt-BR")
 
 		if err != nil {
 			t.Errorf("Error translating text: %v", err)
@@ -58,13 +59,13 @@ func TestTranslateAPOD(t *testing.T) {
 	i18n.InitTranslationService()
 
 	// Test with English (should not modify)
-	i18n.TranslateAPOD(apodData, "en")
+	i18n.TranslateAPOD(context.Background(), apodData, "en")
 	if apodData["title"] != "Amazing Galaxy" {
 		t.Errorf("The text in English should not be modified")
 	}
 
 	// Test with Portuguese
-	i18n.TranslateAPOD(apodData, "pt-BR")
+	i18n.TranslateAPOD(context.Background(), apodData, "pt-BR")
 
 	// With the mock, it should have added [pt-BR] to the title
 	if title, ok := apodData["title"].(string); !ok || title == "Amazing Galaxy" {
@@ -72,6 +73,35 @@ func TestTranslateAPOD(t *testing.T) {
 	}
 }
 
+// TestTranslateAPODFromSourceLanguage checks that APODs are translated from their source language
+func TestTranslateAPODFromSourceLanguage(t *testing.T) {
+	os.Setenv("GOOGLE_TRANSLATE_API_KEY", "")
+	os.Setenv("DEEPL_API_KEY", "")
+	i18n.InitTranslationService()
+
+	newApod := func() map[string]interface{} {
+		return map[string]interface{}{
+			"title":       "Galaxia Asombrosa",
+			"explanation": "Esta es una hermosa galaxia muy lejana.",
+			"source_lang": "es",
+		}
+	}
+
+	// Already in the requested language
+	apodData := newApod()
+	i18n.TranslateAPOD(context.Background(), apodData, "es")
+	if apodData["title"] != "Galaxia Asombrosa" || apodData["translation_source"] != nil {
+		t.Errorf("The text in Spanish should not be modified, got %v", apodData)
+	}
+
+	// English readers get a translation
+	apodData = newApod()
+	i18n.TranslateAPOD(context.Background(), apodData, "en")
+	if apodData["title"] != "Galaxia Asombrosa [en]" {
+		t.Errorf("The text should have been translated to English, got %v", apodData["title"])
+	}
+}
+
 // TestTranslationCache tests the functioning of the translation cache
 func TestTranslationCache(t *testing.T) {
 	cache := i18n.NewTranslationCache()
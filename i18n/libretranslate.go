@@ -0,0 +1,148 @@
+package i18n
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// LibreTranslateRequest represents the request format for the LibreTranslate API
+type LibreTranslateRequest struct {
+	Q      []string `json:"q"`
+	Source string   `json:"source"`
+	Target string   `json:"target"`
+	Format string   `json:"format"`
+	APIKey string   `json:"api_key,omitempty"`
+}
+
+// LibreTranslateResponse represents the response of the LibreTranslate API to a batch request
+type LibreTranslateResponse struct {
+	TranslatedText []string `json:"translatedText"`
+}
+
+// LibreTranslateError represents an error returned by the LibreTranslate API
+type LibreTranslateError struct {
+	Error string `json:"error"`
+}
+
+// LibreTranslateClient implements the translation service using the LibreTranslate API,
+// or any self-hosted server compatible with it
+type LibreTranslateClient struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+	cache      *TranslationCache
+}
+
+// NewLibreTranslateClient creates a new client for the LibreTranslate server at baseURL.
+// The API key is optional, self-hosted servers usually don't require one.
+func NewLibreTranslateClient(baseURL, apiKey string) *LibreTranslateClient {
+	return &LibreTranslateClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		httpClient: &http.Client{
+			// Self-hosted servers translating on CPU are slower than hosted APIs
+			Timeout: 30 * time.Second,
+		},
+		cache: NewTranslationCache(),
+	}
+}
+
+// libreTranslateBatchLimits keep requests small enough for servers started with a character limit
+var libreTranslateBatchLimits = batchLimits{
+	maxItems: 25,
+	maxChars: 10000,
+}
+
+// Translate implements the TranslationService interface for LibreTranslate
+func (c *LibreTranslateClient) Translate(ctx context.Context, text, sourceLang, targetLang string) (string, error) {
+	translated, err := c.TranslateBatch(ctx, []string{text}, sourceLang, targetLang)
+	if err != nil {
+		return "", err
+	}
+	return translated[0], nil
+}
+
+// TranslateBatch implements the TranslationService interface for LibreTranslate,
+// sending up to 25 texts per request
+func (c *LibreTranslateClient) TranslateBatch(ctx context.Context, texts []string, sourceLang, targetLang string) ([]string, error) {
+	return batchTranslate(ctx, c.cache, ProviderLibreTranslate, texts, sourceLang, targetLang, libreTranslateBatchLimits, func(ctx context.Context, chunk []string, format textFormat) ([]string, error) {
+		return c.translateChunk(ctx, chunk, format, sourceLang, targetLang)
+	})
+}
+
+// cachedTranslation returns a translation from the cache without calling the API
+func (c *LibreTranslateClient) cachedTranslation(text, sourceLang, targetLang string) (string, bool) {
+	return c.cache.Get(translationCacheKey(ProviderLibreTranslate, sourceLang, targetLang, cacheGlossaryVersion(sourceLang, targetLang), text))
+}
+
+// cacheStats reports the statistics of the translation cache
+func (c *LibreTranslateClient) cacheStats() TranslationCacheStats {
+	return c.cache.Stats()
+}
+
+// translateChunk sends one request to the LibreTranslate API
+func (c *LibreTranslateClient) translateChunk(ctx context.Context, texts []string, format textFormat, sourceLang, targetLang string) ([]string, error) {
+	// LibreTranslate has no regional variants, like Google (e.g. "pt-BR" -> "pt")
+	reqBody := LibreTranslateRequest{
+		Q:      texts,
+		Source: providerLanguageCode(ProviderLibreTranslate, sourceLang, sanitizeLanguageCode),
+		Target: providerLanguageCode(ProviderLibreTranslate, targetLang, sanitizeLanguageCode),
+		Format: string(format),
+		APIKey: c.apiKey,
+	}
+	if reqBody.Source == "" {
+		reqBody.Source = "auto"
+	}
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing request: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/translate", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error executing request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	// LibreTranslate explains errors (unsupported language, invalid key...) in the body
+	if resp.StatusCode != http.StatusOK {
+		var apiErr LibreTranslateError
+		if json.NewDecoder(resp.Body).Decode(&apiErr) == nil && apiErr.Error != "" {
+			return nil, fmt.Errorf("API returned non-OK status: %d: %s", resp.StatusCode, apiErr.Error)
+		}
+		return nil, fmt.Errorf("API returned non-OK status: %d", resp.StatusCode)
+	}
+
+	var translateResp LibreTranslateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&translateResp); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+	if len(translateResp.TranslatedText) != len(texts) {
+		return nil, fmt.Errorf("expected %d translations, got %d", len(texts), len(translateResp.TranslatedText))
+	}
+
+	return translateResp.TranslatedText, nil
+}
+
+// LibreTranslateURL returns the URL of the LibreTranslate server from the environment
+func LibreTranslateURL() string {
+	return os.Getenv("LIBRETRANSLATE_URL")
+}
+
+// LibreTranslateAPIKey returns the optional LibreTranslate API key from the environment
+func LibreTranslateAPIKey() string {
+	return os.Getenv("LIBRETRANSLATE_API_KEY")
+}
@@ -0,0 +1,156 @@
+package i18n
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// libreTranslateStandIn is a local stand-in for a LibreTranslate server
+type libreTranslateStandIn struct {
+	mutex    sync.Mutex
+	requests []LibreTranslateRequest
+}
+
+func (s *libreTranslateStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" || r.URL.Path != "/translate" {
+		http.NotFound(w, r)
+		return
+	}
+	var req LibreTranslateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(LibreTranslateError{Error: err.Error()})
+		return
+	}
+
+	s.mutex.Lock()
+	s.requests = append(s.requests, req)
+	s.mutex.Unlock()
+
+	if req.Target == "xx" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(LibreTranslateError{Error: "xx is not supported"})
+		return
+	}
+
+	translated := make([]string, len(req.Q))
+	for i, text := range req.Q {
+		translated[i] = fmt.Sprintf("[%s] %s", req.Target, text)
+	}
+	json.NewEncoder(w).Encode(LibreTranslateResponse{TranslatedText: translated})
+}
+
+func (s *libreTranslateStandIn) requestCount() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return len(s.requests)
+}
+
+// TestLibreTranslateBatch checks batching, language codes, the API key and the cache
+func TestLibreTranslateBatch(t *testing.T) {
+	standIn := &libreTranslateStandIn{}
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+
+	client := NewLibreTranslateClient(server.URL+"/", "secret")
+	texts := []string{"Nebula", "", "Galaxy", "Nebula"}
+
+	translated, err := client.TranslateBatch(context.Background(), texts, "en", "pt-BR")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []string{"[pt] Nebula", "", "[pt] Galaxy", "[pt] Nebula"}
+	for i := range expected {
+		if translated[i] != expected[i] {
+			t.Errorf("Text %d: expected %q, got %q", i, expected[i], translated[i])
+		}
+	}
+
+	if standIn.requestCount() != 1 {
+		t.Fatalf("Expected a single request, got %d", standIn.requestCount())
+	}
+	req := standIn.requests[0]
+	if req.Source != "en" || req.Target != "pt" || req.APIKey != "secret" || req.Format != "text" {
+		t.Errorf("Unexpected request: %+v", req)
+	}
+	if len(req.Q) != 2 {
+		t.Errorf("Expected only the 2 distinct texts to be sent, got %v", req.Q)
+	}
+
+	// The second call is answered from the cache
+	if _, err := client.TranslateBatch(context.Background(), []string{"Galaxy"}, "en", "pt-BR"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if standIn.requestCount() != 1 {
+		t.Errorf("Expected cached texts not to be sent again, got %d requests", standIn.requestCount())
+	}
+	if cached, found := client.cachedTranslation("Galaxy", "en", "pt-BR"); !found || cached != "[pt] Galaxy" {
+		t.Errorf("Expected a cached translation, got %q (found: %v)", cached, found)
+	}
+}
+
+// TestLibreTranslateChunks checks that large batches are split by the item limit
+func TestLibreTranslateChunks(t *testing.T) {
+	standIn := &libreTranslateStandIn{}
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+
+	client := NewLibreTranslateClient(server.URL, "")
+	texts := make([]string, libreTranslateBatchLimits.maxItems+5)
+	for i := range texts {
+		texts[i] = fmt.Sprintf("text %d", i)
+	}
+
+	translated, err := client.TranslateBatch(context.Background(), texts, "en", "es")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for i, text := range translated {
+		if text != "[es] "+texts[i] {
+			t.Errorf("Text %d: expected %q, got %q", i, "[es] "+texts[i], text)
+		}
+	}
+	if standIn.requestCount() != 2 {
+		t.Errorf("Expected 2 requests, got %d", standIn.requestCount())
+	}
+	if standIn.requests[0].APIKey != "" {
+		t.Errorf("Expected no API key, got %q", standIn.requests[0].APIKey)
+	}
+}
+
+// TestLibreTranslateError checks that API errors are reported and texts returned unchanged
+func TestLibreTranslateError(t *testing.T) {
+	server := httptest.NewServer(&libreTranslateStandIn{})
+	defer server.Close()
+
+	client := NewLibreTranslateClient(server.URL, "")
+	translated, err := client.TranslateBatch(context.Background(), []string{"Nebula"}, "en", "xx")
+	if err == nil || !strings.Contains(err.Error(), "xx is not supported") {
+		t.Fatalf("Expected the API error, got %v", err)
+	}
+	if translated[0] != "Nebula" {
+		t.Errorf("Expected the original text, got %q", translated[0])
+	}
+}
+
+// TestLibreTranslateProviderFromEnv checks that a configured server joins the default chain
+func TestLibreTranslateProviderFromEnv(t *testing.T) {
+	t.Setenv("TRANSLATION_PROVIDERS", "")
+	t.Setenv("GOOGLE_TRANSLATE_API_KEY", "")
+	t.Setenv("DEEPL_API_KEY", "")
+	t.Setenv("LIBRETRANSLATE_URL", "http://localhost:5000")
+
+	names := translationProvidersFromEnv()
+	if len(names) != 1 || names[0] != ProviderLibreTranslate {
+		t.Fatalf("Expected only LibreTranslate, got %v", names)
+	}
+	if _, ok := newTranslationProvider(ProviderLibreTranslate).(*LibreTranslateClient); !ok {
+		t.Error("Expected a LibreTranslate client")
+	}
+}
@@ -1,199 +1,198 @@
 package i18n
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
+	"fmt"
 	"log"
 	"os"
-	"path/filepath"
-	"strings"
 
 	"github.com/nicksnyder/go-i18n/v2/i18n"
-	"golang.org/x/text/language"
 )
 
-var (
-	// Bundle contains all translated messages
-	Bundle *i18n.Bundle
-	// Languages supported by the API
-	SupportedLanguages = []string{
-		"en",    // English
-		"es",    // Spanish
-		"de",    // German
-		"ja",    // Japanese
-		"fr",    // French
-		"ru",    // Russian
-		"pt-BR", // Portuguese (Brazil)
-		"it",    // Italian
-		"nl",    // Dutch/Flemish
-		"pl",    // Polish
-		"tr",    // Turkish
-		"fa",    // Persian
-		"zh",    // Chinese
-		"vi",    // Vietnamese
-		"id",    // Indonesian
-		"cs",    // Czech
-		"ko",    // Korean
-		"uk",    // Ukrainian
-		"hu",    // Hungarian
-		"ro",    // Romanian		"ar",     // Arabic
-		"sv",    // Swedish
+// InitLocales loads the locale files and the languages they describe: the embedded ones, or
+// those of LOCALES_DIR when it is set. A malformed locale file is an error.
+func InitLocales() error {
+	localesDir = os.Getenv("LOCALES_DIR")
+	if err := LoadLocales(localesDir); err != nil {
+		return err
 	}
-)
-
-// InitLocales initializes the internationalization system
-func InitLocales() {
-	// Create a new bundle with English as the base language
-	Bundle = i18n.NewBundle(language.English)
-	Bundle.RegisterUnmarshalFunc("json", json.Unmarshal)
-
-	// Load translation files
-	if err := loadTranslationFiles(); err != nil {
-		log.Printf("Warning: Failed to load translations: %v", err)
-		log.Println("API will only work with English language")
+	if localesDir != "" {
+		log.Printf("Locales loaded from %s instead of the embedded locale files", localesDir)
 	}
+	return nil
 }
 
-// LoadTranslationFiles loads translation files from the i18n/locales directory
-func loadTranslationFiles() error {
-	localesDir := filepath.Join("i18n", "locales")
-	// Check if directory exists
-	if _, err := os.ReadDir(localesDir); err != nil {
-		// If directory doesn't exist, create it and add default translation files
-		if err := createDefaultTranslationFiles(localesDir); err != nil {
-			return err
-		}
-	}
+// Localizer returns a localizer for the specified language, falling back to English
+// for messages the language doesn't translate
+func Localizer(lang string) *i18n.Localizer {
+	return i18n.NewLocalizer(currentRegistry().bundle, lang, "en")
+}
 
-	// Load all translation files
-	for _, lang := range SupportedLanguages {
-		filename := filepath.Join(localesDir, lang+".json")
-		if _, err := Bundle.LoadMessageFile(filename); err != nil {
-			log.Printf("Error loading translation for %s: %v", lang, err)
-		}
-	}
+// translatableAPODFields are the APOD fields sent to the translation service
+var translatableAPODFields = []string{"title", "explanation", "copyright"}
 
-	return nil
-}
+// Access to the translation store, replaced in tests
+var (
+	findStoredTranslations   = FindStoredTranslations
+	storeMachineTranslations = saveMachineTranslationsAsync
+)
 
-// createDefaultTranslationFiles creates default translation files if they don't exist
-func createDefaultTranslationFiles(localesDir string) error {
-	// Create the directory if it doesn't exist
-	if err := os.MkdirAll(localesDir, 0755); err != nil {
-		return err
-	}
+// TranslateAPOD translates APOD fields to the requested language
+func TranslateAPOD(ctx context.Context, apodData map[string]interface{}, lang string) error {
+	return TranslateAPODs(ctx, []map[string]interface{}{apodData}, lang)
+}
 
-	// Create translation files with basic content
-	translations := map[string]map[string]string{
-		"en": {
-			"apod_title":        "Astronomy Picture of the Day",
-			"apod_not_found":    "Document not found! Please check the date format (YYYY-MM-DD).",
-			"search_no_results": "No documents found matching the search criteria",
-		},
-		"pt-BR": {
-			"apod_title":        "Imagem Astronômica do Dia",
-			"apod_not_found":    "Documento não encontrado! Por favor verifique o formato da data (AAAA-MM-DD).",
-			"search_no_results": "Nenhum documento encontrado para os critérios de busca",
-		},
-		"es": {
-			"apod_title":        "Imagen Astronómica del Día",
-			"apod_not_found":    "¡Documento no encontrado! Por favor verifique el formato de la fecha (AAAA-MM-DD).",
-			"search_no_results": "No se encontraron documentos que coincidan con los criterios de búsqueda",
-		},
-		"fr": {
-			"apod_title":        "Image Astronomique du Jour",
-			"apod_not_found":    "Document non trouvé! Veuillez vérifier le format de la date (AAAA-MM-JJ).",
-			"search_no_results": "Aucun document trouvé correspondant aux critères de recherche",
-		},
+// TranslateAPODs translates the fields of several APODs to the requested language.
+// Each APOD is translated from its "source_lang" (English when missing), and APODs already
+// written in the requested language are left untouched.
+// Approved human translations always win; machine translations already stored in
+// MongoDB are used as long as the original text and the glossary are unchanged. The remaining texts are
+// sent to the translation service in a single batch per source language and stored for
+// the next requests.
+// Each translated APOD gets a "translation_source" field: "human" when every translated
+// field was reviewed by an editor, "machine" otherwise. If the context expires or a
+// provider call fails, the fields translated so far are kept and stored, the others stay in the
+// original language and the error is returned.
+func TranslateAPODs(ctx context.Context, apods []map[string]interface{}, lang string) error {
+	// Without a language there is nothing to translate to
+	if lang == "" {
+		return nil
 	}
 
-	for lang, msgs := range translations {
-		filename := filepath.Join(localesDir, lang+".json")
-
-		// Convert to the format expected by go-i18n
-		i18nMsgs := make(map[string]map[string]string)
-		for id, msg := range msgs {
-			i18nMsgs[id] = map[string]string{"other": msg}
+	// Collect every non-empty field not already in the requested language, remembering where it came from
+	type fieldRef struct {
+		apod       int
+		date       string
+		field      string
+		text       string
+		sourceLang string
+	}
+	var refs []fieldRef
+	var dates []string
+	fieldCounts := make([]int, len(apods))
+	for i, apodData := range apods {
+		sourceLang := APODSourceLanguage(apodData)
+		if sourceLang == lang {
+			continue
 		}
-
-		// Serialize to JSON
-		data, err := json.MarshalIndent(i18nMsgs, "", "  ")
-		if err != nil {
-			return err
+		date, _ := apodData["date"].(string)
+		if date != "" {
+			dates = append(dates, date)
 		}
-		// Write to file
-		if err := os.WriteFile(filename, data, 0644); err != nil {
-			return err
+		for _, field := range translatableAPODFields {
+			if text, ok := apodData[field].(string); ok && text != "" {
+				refs = append(refs, fieldRef{apod: i, date: date, field: field, text: text, sourceLang: sourceLang})
+				fieldCounts[i]++
+			}
 		}
 	}
-
-	return nil
-}
-
-// Localizer returns a localizer for the specified language
-func Localizer(lang string) *i18n.Localizer {
-	// If language is not specified or not supported, use English
-	if lang == "" {
-		lang = "en"
+	if len(refs) == 0 {
+		return nil
 	}
 
-	// Check if language is supported
-	supported := false
-	for _, supportedLang := range SupportedLanguages {
-		if strings.HasPrefix(lang, supportedLang) {
-			supported = true
-			break
+	// Use the stored translations first
+	stored, err := findStoredTranslations(ctx, dates, lang)
+	if err != nil {
+		log.Printf("Error reading stored translations: %v", err)
+	}
+	humanCounts := make([]int, len(apods))
+	missingBySource := make(map[string][]fieldRef)
+	var sourceLangs []string
+	for _, ref := range refs {
+		if translation, found := stored[storedTranslationKey(ref.date, ref.field)]; found && translation.Servable(ref.text, ref.sourceLang) {
+			apods[ref.apod][ref.field] = translation.Text
+			if translation.Source == SourceHuman {
+				humanCounts[ref.apod]++
+			}
+			continue
 		}
+		if _, seen := missingBySource[ref.sourceLang]; !seen {
+			sourceLangs = append(sourceLangs, ref.sourceLang)
+		}
+		missingBySource[ref.sourceLang] = append(missingBySource[ref.sourceLang], ref)
 	}
 
-	if !supported {
-		lang = "en"
+	// Mark where each APOD's translation comes from
+	for i, apodData := range apods {
+		if fieldCounts[i] == 0 {
+			continue
+		}
+		if humanCounts[i] == fieldCounts[i] {
+			apodData["translation_source"] = SourceHuman
+		} else {
+			apodData["translation_source"] = SourceMachine
+		}
 	}
 
-	return i18n.NewLocalizer(Bundle, lang, "en")
-}
-
-// TranslateAPOD translates APOD fields to the requested language
-func TranslateAPOD(apodData map[string]interface{}, lang string) error {
-	// If not a supported language or it's English, return without modifications
-	if lang == "" || lang == "en" {
-		return nil
-	}
+	// One batch per source language, most pages have a single one
+	var errs []error
+	var toStore []StoredTranslation
+	for _, sourceLang := range sourceLangs {
+		missing := missingBySource[sourceLang]
+		texts := make([]string, len(missing))
+		for i, ref := range missing {
+			texts[i] = ref.text
+		}
 
-	// Initialize translation service if not yet initialized
-	if currentService == nil {
-		InitTranslationService()
-	}
+		// Read before translating, so a glossary change during the call invalidates the result
+		glossaryVersion := cacheGlossaryVersion(sourceLang, lang)
+		translated, providers, err := translateTextsWithProvider(ctx, texts, sourceLang, lang)
+		if translated != nil {
+			for i, ref := range missing {
+				apods[ref.apod][ref.field] = translated[i]
+			}
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
 
-	// Translate title
-	if title, ok := apodData["title"].(string); ok && title != "" {
-		translatedTitle, err := TranslateText(title, lang)
-		if err == nil {
-			apodData["title"] = translatedTitle
-		} else {
-			log.Printf("Error translating title: %v", err)
+		// Store the new translations permanently, including those of a batch that failed
+		// halfway (the texts left unchanged and mock output are never stored)
+		if providers == nil {
+			continue
+		}
+		for i, ref := range missing {
+			provider := providers[i]
+			if ref.date == "" || provider == "" || provider == ProviderMock || translated[i] == "" || (err != nil && translated[i] == ref.text) {
+				continue
+			}
+			toStore = append(toStore, StoredTranslation{
+				Date:            ref.date,
+				Lang:            lang,
+				Field:           ref.field,
+				Text:            translated[i],
+				SourceHash:      sourceHash(ref.text),
+				Source:          SourceMachine,
+				Provider:        provider,
+				GlossaryVersion: glossaryVersion,
+			})
 		}
 	}
+	storeMachineTranslations(toStore)
+	if len(errs) > 0 {
+		return fmt.Errorf("error translating APODs: %w", errors.Join(errs...))
+	}
+	return nil
+}
 
-	// Translate explanation
-	if explanation, ok := apodData["explanation"].(string); ok && explanation != "" {
-		translatedExplanation, err := TranslateText(explanation, lang)
-		if err == nil {
-			apodData["explanation"] = translatedExplanation
-		} else {
-			log.Printf("Error translating explanation: %v", err)
-		}
+// APODSourceLanguage returns the language an APOD map is written in, English when it
+// has no "source_lang" field (APODs stored before languages were detected)
+func APODSourceLanguage(apodData map[string]interface{}) string {
+	if sourceLang, ok := apodData["source_lang"].(string); ok && sourceLang != "" {
+		return sourceLang
 	}
+	return "en"
+}
 
-	// Other fields can be translated here if necessary
-	// For example, copyright, etc.
-	if copyright, ok := apodData["copyright"].(string); ok && copyright != "" {
-		translatedCopyright, err := TranslateText(copyright, lang)
-		if err == nil {
-			apodData["copyright"] = translatedCopyright
+// IsTranslatableAPODField reports whether a field of an APOD is translated
+func IsTranslatableAPODField(field string) bool {
+	for _, translatable := range translatableAPODFields {
+		if field == translatable {
+			return true
 		}
 	}
-
-	return nil
+	return false
 }
 
 // Helper function to get the minimum between two integers
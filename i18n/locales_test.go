@@ -0,0 +1,168 @@
+package i18n
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// countingTranslationService tags every text with the target language, counting the texts sent
+type countingTranslationService struct {
+	mutex sync.Mutex
+	texts int
+}
+
+func (s *countingTranslationService) Translate(ctx context.Context, text, sourceLang, targetLang string) (string, error) {
+	translated, err := s.TranslateBatch(ctx, []string{text}, sourceLang, targetLang)
+	return translated[0], err
+}
+
+func (s *countingTranslationService) TranslateBatch(ctx context.Context, texts []string, sourceLang, targetLang string) ([]string, error) {
+	s.mutex.Lock()
+	s.texts += len(texts)
+	s.mutex.Unlock()
+	translated := make([]string, len(texts))
+	for i, text := range texts {
+		translated[i] = "[" + targetLang + "] " + text
+	}
+	return translated, nil
+}
+
+func (s *countingTranslationService) sent() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.texts
+}
+
+// memoryTranslationStore replaces the MongoDB translation store
+type memoryTranslationStore struct {
+	mutex        sync.Mutex
+	translations map[string]StoredTranslation
+}
+
+func (s *memoryTranslationStore) find(ctx context.Context, dates []string, lang string) (map[string]StoredTranslation, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	found := make(map[string]StoredTranslation)
+	for _, translation := range s.translations {
+		for _, date := range dates {
+			if translation.Date == date && translation.Lang == lang {
+				found[storedTranslationKey(date, translation.Field)] = translation
+			}
+		}
+	}
+	return found, nil
+}
+
+func (s *memoryTranslationStore) save(translations []StoredTranslation) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, translation := range translations {
+		s.translations[translation.Date+"|"+translation.Lang+"|"+translation.Field] = translation
+	}
+}
+
+// useTestTranslations replaces the translation service and the translation store for a test
+func useTestTranslations(t *testing.T) (*countingTranslationService, *memoryTranslationStore) {
+	t.Helper()
+	service := &countingTranslationService{}
+	store := &memoryTranslationStore{translations: make(map[string]StoredTranslation)}
+
+	previousService, previousFind, previousStore := currentService, findStoredTranslations, storeMachineTranslations
+	t.Cleanup(func() {
+		currentService, findStoredTranslations, storeMachineTranslations = previousService, previousFind, previousStore
+	})
+	fallback := NewFallbackTranslationService()
+	fallback.AddProvider(ProviderGoogle, service, NewCircuitBreaker(5, time.Minute))
+	currentService = fallback
+	findStoredTranslations = store.find
+	storeMachineTranslations = store.save
+	return service, store
+}
+
+// TestStoredTranslationsFollowGlossary checks that stored translations are reused until the glossary changes
+func TestStoredTranslationsFollowGlossary(t *testing.T) {
+	service, _ := useTestTranslations(t)
+	SetGlossary(NewGlossary([]GlossaryEntry{{Term: "redshift", Lang: "pt-BR", Translation: "desvio para o vermelho"}}))
+	defer SetGlossary(NewGlossary(nil))
+
+	translate := func() {
+		t.Helper()
+		apod := map[string]interface{}{"date": "2025-06-10", "title": "A redshift record"}
+		if err := TranslateAPOD(context.Background(), apod, "pt-BR"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	translate()
+	translate()
+	if sent := service.sent(); sent != 1 {
+		t.Fatalf("Expected the stored translation to be reused, got %d texts sent", sent)
+	}
+
+	SetGlossary(NewGlossary([]GlossaryEntry{{Term: "redshift", Lang: "pt-BR", Translation: "redshift"}}))
+	translate()
+	if sent := service.sent(); sent != 2 {
+		t.Errorf("Expected the title to be retranslated after the glossary change, got %d texts sent", sent)
+	}
+}
+
+// partialTranslationService translates the titles and times out on the other texts,
+// answering the titles from its cache afterwards like the real providers
+type partialTranslationService struct {
+	cache map[string]string
+}
+
+func (s *partialTranslationService) Translate(ctx context.Context, text, sourceLang, targetLang string) (string, error) {
+	translated, err := s.TranslateBatch(ctx, []string{text}, sourceLang, targetLang)
+	return translated[0], err
+}
+
+func (s *partialTranslationService) TranslateBatch(ctx context.Context, texts []string, sourceLang, targetLang string) ([]string, error) {
+	translated := make([]string, len(texts))
+	copy(translated, texts)
+	for i, text := range texts {
+		if strings.HasPrefix(text, "Title") {
+			translated[i] = "[" + targetLang + "] " + text
+			s.cache[text] = translated[i]
+		}
+	}
+	return translated, context.DeadlineExceeded
+}
+
+func (s *partialTranslationService) cachedTranslation(text, sourceLang, targetLang string) (string, bool) {
+	translated, found := s.cache[text]
+	return translated, found
+}
+
+func (s *partialTranslationService) cacheStats() TranslationCacheStats {
+	return TranslationCacheStats{}
+}
+
+// TestPartialBatchTranslationsAreStored checks that the translations of a batch that failed
+// halfway are stored, and the texts left unchanged are not
+func TestPartialBatchTranslationsAreStored(t *testing.T) {
+	_, store := useTestTranslations(t)
+	fallback := NewFallbackTranslationService()
+	fallback.AddProvider(ProviderGoogle, &partialTranslationService{cache: make(map[string]string)}, NewCircuitBreaker(5, time.Minute))
+	currentService = fallback
+
+	apod := map[string]interface{}{"date": "2025-06-10", "title": "Title of the day", "explanation": "A long explanation"}
+	if err := TranslateAPOD(context.Background(), apod, "pt-BR"); err == nil {
+		t.Fatal("Expected the batch error to be returned")
+	}
+	if apod["title"] != "[pt-BR] Title of the day" || apod["explanation"] != "A long explanation" {
+		t.Errorf("Unexpected response: %+v", apod)
+	}
+
+	stored, _ := store.find(context.Background(), []string{"2025-06-10"}, "pt-BR")
+	if len(stored) != 1 {
+		t.Fatalf("Expected only the title to be stored, got %+v", stored)
+	}
+	title, found := stored[storedTranslationKey("2025-06-10", "title")]
+	if !found || title.Text != "[pt-BR] Title of the day" || title.Provider != ProviderGoogle {
+		t.Errorf("Unexpected stored title: %+v", title)
+	}
+}
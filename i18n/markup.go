@@ -0,0 +1,65 @@
+package i18n
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// textFormat tells a provider how to read the texts of a chunk
+type textFormat string
+
+const (
+	// formatText is plain text, translated as is
+	formatText textFormat = "text"
+	// formatHTML is text with inline markup: providers translate the text between the
+	// tags and leave the tags and their attributes (e.g. link targets) untouched
+	formatHTML textFormat = "html"
+)
+
+// markupPattern matches an HTML opening or closing tag, e.g. <a href="..."> or </i>
+var markupPattern = regexp.MustCompile(`</?[a-zA-Z][a-zA-Z0-9]*(?:\s[^<>]*)?/?>`)
+
+// protectedPattern matches the parts of a text providers must never translate: URLs and
+// astronomical catalogue numbers (M31, NGC 7000, IC 434, Sh2-155, HD 209458...)
+var protectedPattern = regexp.MustCompile(`https?://[^\s<>"']*[^\s<>"'.,;:!?)]` +
+	`|\b(?:M|NGC|IC|UGC|PGC|Arp|Abell|Barnard|LDN|LBN|vdB|HD|HIP|HR)\s?\d+[A-Za-z]?\b` +
+	`|\bSh\s?2-\d+\b`)
+
+// protectedPlaceholderPattern matches the placeholders of protected parts, tolerating
+// spaces added by providers. They differ from the glossary placeholders so both can be
+// used on the same text.
+var protectedPlaceholderPattern = regexp.MustCompile(`⟦\s*#\s*(\d+)\s*⟧`)
+
+// detectFormat returns the format of a text: HTML when it contains markup, plain text otherwise
+func detectFormat(text string) textFormat {
+	if strings.ContainsRune(text, '<') && markupPattern.MatchString(text) {
+		return formatHTML
+	}
+	return formatText
+}
+
+// protectText replaces the URLs and catalogue numbers of a text with numbered
+// placeholders, returning the protected text and the original of each placeholder
+func protectText(text string) (string, []string) {
+	var originals []string
+	protected := protectedPattern.ReplaceAllStringFunc(text, func(match string) string {
+		originals = append(originals, match)
+		return "⟦#" + strconv.Itoa(len(originals)-1) + "⟧"
+	})
+	return protected, originals
+}
+
+// restoreText puts back the parts replaced by protectText
+func restoreText(text string, originals []string) string {
+	if len(originals) == 0 {
+		return text
+	}
+	return protectedPlaceholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		index, err := strconv.Atoi(protectedPlaceholderPattern.FindStringSubmatch(placeholder)[1])
+		if err != nil || index >= len(originals) {
+			return placeholder
+		}
+		return originals[index]
+	})
+}
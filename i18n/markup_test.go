@@ -0,0 +1,87 @@
+package i18n
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestProtectText checks that URLs and catalogue numbers are replaced and restored
+func TestProtectText(t *testing.T) {
+	text := "M31 and NGC 7000 (see https://apod.nasa.gov/apod/ap250101.html). Sh2-155 is nearby."
+	protected, originals := protectText(text)
+
+	for _, original := range []string{"M31", "NGC 7000", "https://apod.nasa.gov/apod/ap250101.html", "Sh2-155"} {
+		if strings.Contains(protected, original) {
+			t.Errorf("Expected %q to be protected in %q", original, protected)
+		}
+	}
+	if !strings.HasSuffix(protected, "⟦#2⟧). ⟦#3⟧ is nearby.") {
+		t.Errorf("Expected the trailing punctuation to stay out of the URL, got %q", protected)
+	}
+
+	// Providers sometimes add spaces inside placeholders
+	translated := strings.Replace(protected, "⟦#1⟧", "⟦ #1 ⟧", 1)
+	if restored := restoreText(translated, originals); restored != text {
+		t.Errorf("Expected %q, got %q", text, restored)
+	}
+}
+
+// TestDetectFormat checks that only texts with tags are sent as HTML
+func TestDetectFormat(t *testing.T) {
+	tests := map[string]textFormat{
+		`See <a href="https://apod.nasa.gov/">the archive</a>.`: formatHTML,
+		"A <i>beautiful</i> nebula.":                            formatHTML,
+		"Stars brighter than magnitude < 6 are visible.":        formatText,
+		"Plain explanation.":                                    formatText,
+	}
+	for text, expected := range tests {
+		if format := detectFormat(text); format != expected {
+			t.Errorf("detectFormat(%q) = %q; expected %q", text, format, expected)
+		}
+	}
+}
+
+// TestBatchTranslateMarkup checks that texts with markup are sent in HTML mode, apart
+// from plain texts, with their links protected
+func TestBatchTranslateMarkup(t *testing.T) {
+	// Chunks are translated concurrently
+	var mutex sync.Mutex
+	sent := make(map[textFormat][]string)
+	translateChunk := func(ctx context.Context, chunk []string, format textFormat) ([]string, error) {
+		mutex.Lock()
+		sent[format] = append(sent[format], chunk...)
+		mutex.Unlock()
+		translated := make([]string, len(chunk))
+		for i, text := range chunk {
+			translated[i] = strings.ReplaceAll(text, "galaxy", "galáxia")
+		}
+		return translated, nil
+	}
+
+	texts := []string{
+		"The M31 galaxy",
+		`The <a href="https://apod.nasa.gov/apod/ap250101.html">M31</a> galaxy`,
+	}
+	results, err := batchTranslate(context.Background(), NewTranslationCache(), "test", texts, "en", "pt-BR", batchLimits{maxItems: 10, maxChars: 1000}, translateChunk)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(sent[formatText]) != 1 || len(sent[formatHTML]) != 1 {
+		t.Fatalf("Expected one plain and one HTML text, got %v", sent)
+	}
+	if strings.Contains(sent[formatHTML][0], "https://") || strings.Contains(sent[formatHTML][0], "M31") {
+		t.Errorf("Expected the link and catalogue number to be protected, got %q", sent[formatHTML][0])
+	}
+	expected := []string{
+		"The M31 galáxia",
+		`The <a href="https://apod.nasa.gov/apod/ap250101.html">M31</a> galáxia`,
+	}
+	for i := range expected {
+		if results[i] != expected[i] {
+			t.Errorf("Text %d: expected %q, got %q", i, expected[i], results[i])
+		}
+	}
+}
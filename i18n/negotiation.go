@@ -0,0 +1,49 @@
+package i18n
+
+import (
+	"fmt"
+
+	"golang.org/x/text/language"
+)
+
+// matchLanguage returns the supported language closest to the tags, false when none is
+func matchLanguage(tags ...language.Tag) (string, bool) {
+	r := currentRegistry()
+	_, index, confidence := r.matcher.Match(tags...)
+	if confidence == language.No || index >= len(r.languages) {
+		return "", false
+	}
+	return r.languages[index].Code, true
+}
+
+// MatchAcceptLanguage picks the best supported language for an Accept-Language header,
+// honouring q-values and regional fallbacks (e.g. "pt-PT" -> "pt-BR", "zh-TW" -> "zh").
+// It returns "en" when the header is empty, malformed or matches nothing.
+func MatchAcceptLanguage(header string) string {
+	if header == "" {
+		return "en"
+	}
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil || len(tags) == 0 {
+		return "en"
+	}
+	if lang, ok := matchLanguage(tags...); ok {
+		return lang
+	}
+	return "en"
+}
+
+// ParseLanguage resolves an explicitly requested language (the lang parameter) to a
+// supported language, with the same regional fallbacks as MatchAcceptLanguage. Unknown
+// or unsupported languages are an error.
+func ParseLanguage(lang string) (string, error) {
+	tag, err := language.Parse(lang)
+	if err != nil {
+		return "", fmt.Errorf("invalid language %q", lang)
+	}
+	supported, ok := matchLanguage(tag)
+	if !ok {
+		return "", fmt.Errorf("unsupported language %q", lang)
+	}
+	return supported, nil
+}
@@ -0,0 +1,68 @@
+package i18n
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// precomputeTimeout bounds the translation of one APOD into one language
+const precomputeTimeout = 2 * time.Minute
+
+// precomputeQueue holds APODs waiting to be translated into every supported language
+var precomputeQueue chan map[string]interface{}
+
+// StartPrecomputeWorker starts the background job that translates newly ingested APODs
+// into every supported language, so reads are served from the translation store
+func StartPrecomputeWorker() {
+	if precomputeQueue != nil {
+		return
+	}
+	precomputeQueue = make(chan map[string]interface{}, 100)
+
+	go func() {
+		for apodData := range precomputeQueue {
+			PrecomputeTranslations(apodData)
+		}
+	}()
+}
+
+// EnqueuePrecompute schedules an APOD for translation into every supported language.
+// It never blocks: when the worker is not running or the queue is full the APOD is
+// skipped and translated on the first read instead.
+func EnqueuePrecompute(apodData map[string]interface{}) bool {
+	if precomputeQueue == nil {
+		return false
+	}
+	select {
+	case precomputeQueue <- apodData:
+		return true
+	default:
+		log.Printf("Translation precompute queue full, skipping APOD %v", apodData["date"])
+		return false
+	}
+}
+
+// PrecomputeTranslations translates an APOD into every supported language other than its
+// own, storing the results
+func PrecomputeTranslations(apodData map[string]interface{}) {
+	sourceLang := APODSourceLanguage(apodData)
+	for _, lang := range SupportedLanguages() {
+		if lang == sourceLang {
+			continue
+		}
+
+		// Work on a copy, only the stored translations matter
+		apodCopy := make(map[string]interface{}, len(apodData))
+		for key, value := range apodData {
+			apodCopy[key] = value
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), precomputeTimeout)
+		if err := TranslateAPOD(ctx, apodCopy, lang); err != nil {
+			log.Printf("Error precomputing %s translation of APOD %v: %v", lang, apodData["date"], err)
+		}
+		cancel()
+	}
+	log.Printf("Precomputed translations of APOD %v", apodData["date"])
+}
@@ -6,11 +6,18 @@ import (
 	"fmt"
 	"log"
 	"time"
+
+	"github.com/go-redis/redis/v8"
 )
 
+// redisTranslationTimeout bounds every Redis call, so a slow Redis doesn't hold up translations
+const redisTranslationTimeout = 500 * time.Millisecond
+
 // RedisTranslationCache implements a translation cache using Redis
 // to persist translations between server restarts
 type RedisTranslationCache struct {
+	client *redis.Client
+	store  *cache.RedisCache
 	// Prefix to avoid collisions with other keys in Redis
 	prefix string
 	// Expiration time for stored translations
@@ -18,8 +25,10 @@ type RedisTranslationCache struct {
 }
 
 // NewRedisTranslationCache creates a new instance of Redis cache for translations
-func NewRedisTranslationCache() *RedisTranslationCache {
+func NewRedisTranslationCache(client *redis.Client) *RedisTranslationCache {
 	return &RedisTranslationCache{
+		client:     client,
+		store:      cache.NewRedisCache(client),
 		prefix:     "translation:",
 		expiration: 30 * 24 * time.Hour, // 30 days cache
 	}
@@ -27,16 +36,11 @@ func NewRedisTranslationCache() *RedisTranslationCache {
 
 // Get retrieves a translation from Redis cache
 func (c *RedisTranslationCache) Get(key string) (string, bool) {
-	// If Redis is not configured, return not found
-	if cache.Client == nil {
-		return "", false
-	}
-
-	ctx := context.Background()
-	redisKey := c.prefix + key
+	ctx, cancel := context.WithTimeout(context.Background(), redisTranslationTimeout)
+	defer cancel()
 
 	var result string
-	found, err := cache.Get(ctx, redisKey, &result)
+	found, err := c.store.Get(ctx, c.prefix+key, &result)
 	if err != nil {
 		log.Printf("Error accessing Redis cache for translation: %v", err)
 		return "", false
@@ -47,15 +51,10 @@ func (c *RedisTranslationCache) Get(key string) (string, bool) {
 
 // Set stores a translation in Redis cache
 func (c *RedisTranslationCache) Set(key string, value string) {
-	// If Redis is not configured, do nothing
-	if cache.Client == nil {
-		return
-	}
-
-	ctx := context.Background()
-	redisKey := c.prefix + key
+	ctx, cancel := context.WithTimeout(context.Background(), redisTranslationTimeout)
+	defer cancel()
 
-	if err := cache.Set(ctx, redisKey, value, c.expiration); err != nil {
+	if err := c.store.Set(ctx, c.prefix+key, value, c.expiration); err != nil {
 		log.Printf("Error storing translation in Redis cache: %v", err)
 	}
 }
@@ -63,21 +62,21 @@ func (c *RedisTranslationCache) Set(key string, value string) {
 // Clear removes all translations from Redis cache with the specified prefix
 func (c *RedisTranslationCache) Clear() {
 	// If Redis is not configured, do nothing
-	if cache.Client == nil {
+	if c.client == nil {
 		return
 	}
 
 	ctx := context.Background()
 	// Uses the KEYS command to find all keys with the prefix (less efficient but simpler)
 	pattern := fmt.Sprintf("%s*", c.prefix)
-	keys, err := cache.Client.Keys(ctx, pattern).Result()
+	keys, err := c.client.Keys(ctx, pattern).Result()
 	if err != nil {
 		log.Printf("Error fetching translation keys from Redis: %v", err)
 		return
 	}
 
 	if len(keys) > 0 {
-		if err := cache.Client.Del(ctx, keys...).Err(); err != nil {
+		if err := c.client.Del(ctx, keys...).Err(); err != nil {
 			log.Printf("Error deleting translation keys from Redis: %v", err)
 		}
 	}
@@ -0,0 +1,317 @@
+package i18n
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+// metaKey is the entry of a locale file describing its language instead of holding a message
+const metaKey = "_meta"
+
+// LanguageMeta is the "_meta" entry of a locale file
+type LanguageMeta struct {
+	// English name of the language
+	Name string `json:"name"`
+	// Name of the language in the language itself
+	NativeName string `json:"native_name"`
+	// Text direction, "ltr" (default) or "rtl"
+	Dir string `json:"dir,omitempty"`
+	// Language code of each translation provider, when it differs from the one derived
+	// from the locale code (e.g. {"deepl": "ZH-HANS"})
+	Providers map[string]string `json:"providers,omitempty"`
+}
+
+// Language is a supported language, discovered from its locale file
+type Language struct {
+	// Language code, the name of the locale file
+	// example: pt-BR
+	Code string `json:"code"`
+	// English name
+	// example: Brazilian Portuguese
+	Name string `json:"name"`
+	// Name in the language itself
+	// example: Português do Brasil
+	NativeName string `json:"nativeName"`
+	// Text direction
+	// example: ltr
+	Dir string `json:"dir" enums:"ltr,rtl"`
+	// Number of English messages translated in the locale
+	// example: 34
+	Messages int `json:"messages"`
+	// Whether CLDR has a long date format for the language; dates are formatted in
+	// English otherwise
+	// example: true
+	DateFormat bool `json:"dateFormat"`
+	// Share of the English messages translated in the locale, the date format counting
+	// as one more (0 to 1)
+	// example: 1
+	Completeness float64 `json:"completeness"`
+
+	providers map[string]string
+}
+
+// languageRegistry holds the languages and messages of one load of the locale files
+type languageRegistry struct {
+	languages []Language // English first, then by code
+	byCode    map[string]*Language
+	bundle    *i18n.Bundle
+	matcher   language.Matcher
+	version   string // Fingerprint of the locale files
+}
+
+var (
+	// embeddedLocales are the locale files built into the binary
+	//go:embed locales/*.json
+	embeddedLocales embed.FS
+	// localesDir is a directory of locale files replacing the embedded ones, set from
+	// LOCALES_DIR for local development. Empty uses the embedded locale files.
+	localesDir string
+	// registry is the current language registry, replaced as a whole on reload
+	registry atomic.Pointer[languageRegistry]
+)
+
+// englishOnlyRegistry is used when the locale files can't be read
+func englishOnlyRegistry() *languageRegistry {
+	english := Language{Code: "en", Name: "English", NativeName: "English", Dir: DirLTR, DateFormat: true}
+	return newLanguageRegistry([]Language{english}, i18n.NewBundle(language.English), "")
+}
+
+// newLanguageRegistry indexes the languages and builds their matcher
+func newLanguageRegistry(languages []Language, bundle *i18n.Bundle, version string) *languageRegistry {
+	r := &languageRegistry{
+		languages: languages,
+		byCode:    make(map[string]*Language, len(languages)),
+		bundle:    bundle,
+		version:   version,
+	}
+	tags := make([]language.Tag, len(languages))
+	for i := range r.languages {
+		r.byCode[r.languages[i].Code] = &r.languages[i]
+		tags[i] = language.Make(r.languages[i].Code)
+	}
+	r.matcher = language.NewMatcher(tags)
+	return r
+}
+
+// currentRegistry returns the language registry, loading the locale files on first use
+func currentRegistry() *languageRegistry {
+	if r := registry.Load(); r != nil {
+		return r
+	}
+	loaded, err := loadLanguageRegistry(localesFS(localesDir))
+	if err != nil {
+		log.Printf("Warning: Failed to load locales: %v", err)
+		log.Println("API will only work with English language")
+		loaded = englishOnlyRegistry()
+	}
+	registry.CompareAndSwap(nil, loaded)
+	return registry.Load()
+}
+
+// localesFS returns the locale files of a directory, or the embedded ones when dir is empty
+func localesFS(dir string) fs.FS {
+	if dir == "" {
+		locales, _ := fs.Sub(embeddedLocales, "locales")
+		return locales
+	}
+	return os.DirFS(dir)
+}
+
+// loadLanguageRegistry reads every locale file of a file system. A locale file is named
+// after its language code (e.g. pt-BR.json) and describes its language in a "_meta"
+// entry; the other entries are go-i18n messages. The English locale is required, and a
+// malformed locale file is an error.
+func loadLanguageRegistry(locales fs.FS) (*languageRegistry, error) {
+	files, err := fs.Glob(locales, "*.json")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	bundle := i18n.NewBundle(language.English)
+	bundle.RegisterUnmarshalFunc("json", json.Unmarshal)
+	fingerprint := sha256.New()
+	var languages []Language
+	messageIDs := make(map[string]map[string]bool)
+
+	for _, file := range files {
+		code := strings.TrimSuffix(path.Base(file), ".json")
+		if _, err := language.Parse(code); err != nil {
+			log.Printf("Skipping locale file %s: %q is not a language code", file, code)
+			continue
+		}
+		data, err := fs.ReadFile(locales, file)
+		if err != nil {
+			return nil, err
+		}
+		fingerprint.Write([]byte(file))
+		fingerprint.Write(data)
+
+		var entries map[string]json.RawMessage
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return nil, fmt.Errorf("locale file %s: %w", file, err)
+		}
+		var meta LanguageMeta
+		if raw, ok := entries[metaKey]; ok {
+			if err := json.Unmarshal(raw, &meta); err != nil {
+				return nil, fmt.Errorf("locale file %s: invalid %s: %w", file, metaKey, err)
+			}
+			delete(entries, metaKey)
+		}
+
+		messages, err := json.Marshal(entries)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := bundle.ParseMessageFileBytes(messages, file); err != nil {
+			return nil, fmt.Errorf("locale file %s: %w", file, err)
+		}
+
+		messageIDs[code] = make(map[string]bool, len(entries))
+		for id := range entries {
+			messageIDs[code][id] = true
+		}
+		languages = append(languages, newLanguage(code, meta))
+	}
+
+	english, ok := messageIDs["en"]
+	if !ok {
+		return nil, fmt.Errorf("no English locale (en.json)")
+	}
+
+	// Completeness of each locale against the English messages and the date format
+	for i := range languages {
+		for id := range english {
+			if messageIDs[languages[i].Code][id] {
+				languages[i].Messages++
+			}
+		}
+		translated := languages[i].Messages
+		if languages[i].DateFormat {
+			translated++
+		}
+		languages[i].Completeness = float64(translated) / float64(len(english)+1)
+	}
+
+	// English first: it is the default of the matcher
+	sort.SliceStable(languages, func(i, j int) bool {
+		return languages[i].Code == "en" && languages[j].Code != "en"
+	})
+	return newLanguageRegistry(languages, bundle, hex.EncodeToString(fingerprint.Sum(nil))), nil
+}
+
+// newLanguage builds a language from its locale metadata, defaulting the missing fields
+func newLanguage(code string, meta LanguageMeta) Language {
+	lang := Language{
+		Code:       code,
+		Name:       meta.Name,
+		NativeName: meta.NativeName,
+		Dir:        DirLTR,
+		providers:  meta.Providers,
+	}
+	_, lang.DateFormat = lookupDateFormat(code)
+	if lang.Name == "" {
+		lang.Name = code
+	}
+	if lang.NativeName == "" {
+		lang.NativeName = lang.Name
+	}
+	if meta.Dir == DirRTL {
+		lang.Dir = DirRTL
+	}
+	return lang
+}
+
+// LoadLocales loads the locale files of a directory, or the embedded ones when dir is
+// empty, and makes them the current languages. When the files can't be read or are
+// malformed, the current languages are kept.
+func LoadLocales(dir string) error {
+	loaded, err := loadLanguageRegistry(localesFS(dir))
+	if err != nil {
+		if dir != "" {
+			return fmt.Errorf("%s: %w", dir, err)
+		}
+		return err
+	}
+	if current := registry.Load(); current != nil && current.version == loaded.version {
+		return nil
+	}
+	log.Printf("Locales loaded: %d languages", len(loaded.languages))
+	registry.Store(loaded)
+	return nil
+}
+
+// StartLocaleReload reloads the locale files of LOCALES_DIR periodically, so new or edited
+// locales are served without a restart. The embedded locale files never change, so nothing
+// is reloaded without LOCALES_DIR.
+func StartLocaleReload(interval time.Duration) {
+	if localesDir == "" {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := LoadLocales(localesDir); err != nil {
+				log.Printf("Error reloading locales: %v", err)
+			}
+		}
+	}()
+}
+
+// Languages returns the supported languages, English first
+func Languages() []Language {
+	languages := currentRegistry().languages
+	copied := make([]Language, len(languages))
+	copy(copied, languages)
+	return copied
+}
+
+// SupportedLanguages returns the codes of the supported languages, English first
+func SupportedLanguages() []string {
+	languages := currentRegistry().languages
+	codes := make([]string, len(languages))
+	for i, lang := range languages {
+		codes[i] = lang.Code
+	}
+	return codes
+}
+
+// IsSupportedLanguage reports whether a language code is one of the supported languages
+func IsSupportedLanguage(lang string) bool {
+	_, ok := currentRegistry().byCode[lang]
+	return ok
+}
+
+// TextDirection returns the direction a language is written in, "rtl" or "ltr"
+func TextDirection(lang string) string {
+	if info, ok := currentRegistry().byCode[lang]; ok {
+		return info.Dir
+	}
+	return DirLTR
+}
+
+// providerLanguageCode returns the code a provider uses for a language: the one declared
+// in the locale metadata, or the one derived from the language code
+func providerLanguageCode(provider, lang string, derive func(string) string) string {
+	if info, ok := currentRegistry().byCode[lang]; ok {
+		if code := info.providers[provider]; code != "" {
+			return code
+		}
+	}
+	return derive(lang)
+}
@@ -0,0 +1,146 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeLocale writes a locale file in a directory
+func writeLocale(t *testing.T, dir, lang, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, lang+".json"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestLanguagesFromLocaleFiles checks that the shipped locales describe their language
+func TestLanguagesFromLocaleFiles(t *testing.T) {
+	languages := Languages()
+	if len(languages) != 22 || languages[0].Code != "en" {
+		t.Fatalf("Expected 22 languages with English first, got %+v", languages)
+	}
+	for _, lang := range languages {
+		if lang.Name == lang.Code || lang.NativeName == "" {
+			t.Errorf("Expected %s to have a name and a native name, got %+v", lang.Code, lang)
+		}
+		if lang.Completeness != 1 {
+			t.Errorf("Expected %s to be complete, got %v", lang.Code, lang.Completeness)
+		}
+	}
+
+	for lang, expected := range map[string]string{"ar": DirRTL, "fa": DirRTL, "en": DirLTR, "ja": DirLTR, "xx": DirLTR} {
+		if dir := TextDirection(lang); dir != expected {
+			t.Errorf("Expected %s to be %s, got %s", lang, expected, dir)
+		}
+	}
+}
+
+// TestLoadLocalesCompletenessAndReload checks the completeness of a partial locale and that a
+// new locale file is picked up by a reload
+func TestLoadLocalesCompletenessAndReload(t *testing.T) {
+	defer LoadLocales("")
+
+	dir := t.TempDir()
+	writeLocale(t, dir, "en", `{"_meta": {"name": "English", "native_name": "English"}, "a": {"other": "A"}, "b": {"other": "B"}}`)
+	writeLocale(t, dir, "eo", `{"_meta": {"name": "Esperanto", "native_name": "Esperanto"}, "a": {"other": "A eo"}}`)
+	if err := LoadLocales(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	if IsSupportedLanguage("fa") {
+		t.Error("Expected fa not to be supported without its locale file")
+	}
+	languages := Languages()
+	if len(languages) != 2 || languages[1].Code != "eo" || languages[1].Messages != 1 || !languages[1].DateFormat || languages[1].Completeness != 2.0/3 {
+		t.Fatalf("Expected Esperanto to miss one message, got %+v", languages)
+	}
+	if lang, err := ParseLanguage("eo"); err != nil || lang != "eo" {
+		t.Errorf("Expected eo to be negotiated, got %q (%v)", lang, err)
+	}
+
+	writeLocale(t, dir, "fa", `{"_meta": {"name": "Persian", "native_name": "فارسی", "dir": "rtl"}, "a": {"other": "A fa"}}`)
+	if err := LoadLocales(dir); err != nil {
+		t.Fatal(err)
+	}
+	if !IsSupportedLanguage("fa") || TextDirection("fa") != DirRTL {
+		t.Errorf("Expected the reload to add Persian as rtl, got %+v", Languages())
+	}
+}
+
+// TestLoadLocalesWithoutDateFormat checks that a locale without a usable CLDR date format
+// is reported incomplete even with every message translated
+func TestLoadLocalesWithoutDateFormat(t *testing.T) {
+	defer LoadLocales("")
+
+	dir := t.TempDir()
+	writeLocale(t, dir, "en", `{"_meta": {"name": "English", "native_name": "English"}, "a": {"other": "A"}}`)
+	writeLocale(t, dir, "mn", `{"_meta": {"name": "Mongolian", "native_name": "Монгол"}, "a": {"other": "A mn"}}`)
+	if err := LoadLocales(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	languages := Languages()
+	if len(languages) != 2 || languages[1].DateFormat || languages[1].Completeness != 0.5 {
+		t.Fatalf("Expected Mongolian to be incomplete without a date format, got %+v", languages)
+	}
+	if formatted, _ := FormatDate("2025-06-10", "mn"); formatted != "June 10, 2025" {
+		t.Errorf("Expected the English date format, got %q", formatted)
+	}
+}
+
+// TestLoadLocalesRequiresEnglish checks that a directory without English keeps the current languages
+func TestLoadLocalesRequiresEnglish(t *testing.T) {
+	before := len(SupportedLanguages())
+	dir := t.TempDir()
+	writeLocale(t, dir, "eo", `{"a": {"other": "A eo"}}`)
+
+	if err := LoadLocales(dir); err == nil {
+		t.Error("Expected an error without an English locale")
+	}
+	if after := len(SupportedLanguages()); after != before {
+		t.Errorf("Expected the %d current languages to be kept, got %d", before, after)
+	}
+}
+
+// TestLoadLocalesRejectsMalformedFiles checks that a malformed override directory is an error
+func TestLoadLocalesRejectsMalformedFiles(t *testing.T) {
+	defer LoadLocales("")
+
+	testCases := map[string]string{
+		"invalid JSON":     `{"a": {"other": "A"}`,
+		"invalid metadata": `{"_meta": "English", "a": {"other": "A"}}`,
+		"invalid message":  `{"a": 42}`,
+	}
+	for name, content := range testCases {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeLocale(t, dir, "en", content)
+			if err := LoadLocales(dir); err == nil {
+				t.Error("Expected an error for a malformed locale file")
+			}
+		})
+	}
+}
+
+// TestProviderLanguageCode checks the provider codes declared in the locale metadata
+func TestProviderLanguageCode(t *testing.T) {
+	testCases := []struct {
+		provider string
+		lang     string
+		derive   func(string) string
+		expected string
+	}{
+		{ProviderDeepL, "zh", adaptLanguageForDeepL, "ZH-HANS"},
+		{ProviderGoogle, "zh", sanitizeLanguageCode, "zh-CN"},
+		{ProviderDeepL, "pt-BR", adaptLanguageForDeepL, "PT-BR"},
+		{ProviderGoogle, "pt-BR", sanitizeLanguageCode, "pt"},
+		{ProviderLibreTranslate, "zh", sanitizeLanguageCode, "zh"},
+	}
+
+	for _, tc := range testCases {
+		if code := providerLanguageCode(tc.provider, tc.lang, tc.derive); code != tc.expected {
+			t.Errorf("Expected %s code of %s to be %s, got %s", tc.provider, tc.lang, tc.expected, code)
+		}
+	}
+}
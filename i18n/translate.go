@@ -2,6 +2,7 @@ package i18n
 
 import (
 	"astrovista-api/cache"
+	"context"
 	"fmt"
 	"log"
 	"strings"
@@ -9,14 +10,17 @@ import (
 
 // TranslationService defines the interface for translation services
 type TranslationService interface {
-	Translate(text, sourceLang, targetLang string) (string, error)
+	Translate(ctx context.Context, text, sourceLang, targetLang string) (string, error)
+	// TranslateBatch translates several texts at once, returning them in the same order.
+	// On error, the texts that could not be translated are returned unchanged.
+	TranslateBatch(ctx context.Context, texts []string, sourceLang, targetLang string) ([]string, error)
 }
 
 // mockTranslationService is a mock implementation for development
 type mockTranslationService struct{}
 
 // Translate in the mock implementation just adds a language indicator
-func (s *mockTranslationService) Translate(text, sourceLang, targetLang string) (string, error) {
+func (s *mockTranslationService) Translate(ctx context.Context, text, sourceLang, targetLang string) (string, error) {
 	if len(text) > 100 {
 		// For long explanations, we truncate for simulation
 		return fmt.Sprintf("%s... [Translated to %s]", text[:100], targetLang), nil
@@ -24,13 +28,18 @@ func (s *mockTranslationService) Translate(text, sourceLang, targetLang string)
 	return fmt.Sprintf("%s [%s]", text, targetLang), nil
 }
 
+// TranslateBatch in the mock implementation translates each text separately
+func (s *mockTranslationService) TranslateBatch(ctx context.Context, texts []string, sourceLang, targetLang string) ([]string, error) {
+	return translateEach(ctx, s, texts, sourceLang, targetLang)
+}
+
 // googleTranslationService would be a real implementation using the Google Translate API
 type googleTranslationService struct {
 	apiKey string
 }
 
 // Translate in the Google implementation (sketch)
-func (s *googleTranslationService) Translate(text, sourceLang, targetLang string) (string, error) {
+func (s *googleTranslationService) Translate(ctx context.Context, text, sourceLang, targetLang string) (string, error) {
 	// Here would be the code to call the Google Translate API
 	// For now, we just simulate
 	log.Printf("Simulating translation of '%s' from '%s' to '%s'",
@@ -38,13 +47,18 @@ func (s *googleTranslationService) Translate(text, sourceLang, targetLang string
 	return text + " [Google Translated]", nil
 }
 
+// TranslateBatch in the Google implementation (sketch)
+func (s *googleTranslationService) TranslateBatch(ctx context.Context, texts []string, sourceLang, targetLang string) ([]string, error) {
+	return translateEach(ctx, s, texts, sourceLang, targetLang)
+}
+
 // deepLTranslationService would be a real implementation using the DeepL API
 type deepLTranslationService struct {
 	apiKey string
 }
 
 // Translate in the DeepL implementation (sketch)
-func (s *deepLTranslationService) Translate(text, sourceLang, targetLang string) (string, error) {
+func (s *deepLTranslationService) Translate(ctx context.Context, text, sourceLang, targetLang string) (string, error) {
 	// Here would be the code to call the DeepL API
 	// For now, we just simulate
 	log.Printf("Simulating DeepL translation of '%s' from '%s' to '%s'",
@@ -52,13 +66,70 @@ func (s *deepLTranslationService) Translate(text, sourceLang, targetLang string)
 	return text + " [DeepL Translated]", nil
 }
 
+// TranslateBatch in the DeepL implementation (sketch)
+func (s *deepLTranslationService) TranslateBatch(ctx context.Context, texts []string, sourceLang, targetLang string) ([]string, error) {
+	return translateEach(ctx, s, texts, sourceLang, targetLang)
+}
+
+// translateEach implements TranslateBatch for services without a batch API,
+// translating the texts concurrently
+func translateEach(ctx context.Context, service TranslationService, texts []string, sourceLang, targetLang string) ([]string, error) {
+	translated := make([]string, len(texts))
+	copy(translated, texts)
+
+	chunks := make([][]string, len(texts))
+	for i, text := range texts {
+		chunks[i] = []string{text}
+	}
+
+	_, err := translateConcurrently(ctx, chunks, func(ctx context.Context, index int, chunk []string) ([]string, error) {
+		result, err := service.Translate(ctx, chunk[0], sourceLang, targetLang)
+		if err != nil {
+			return nil, err
+		}
+		return []string{result}, nil
+	}, func(index int, result []string) {
+		translated[index] = result[0]
+	}, nil)
+	return translated, err
+}
+
 // Current translation service
 var currentService TranslationService
 
 // InitTranslationService initializes the appropriate translation service
 func InitTranslationService() {
-	// Check which service to use based on environment variables
-	if apiKey := GoogleTranslateAPIKey(); apiKey != "" {
+	loadConcurrencySettings()
+	initTranslationUsage()
+
+	// Build the fallback chain in the configured order
+	fallback := NewFallbackTranslationService()
+	for _, name := range translationProvidersFromEnv() {
+		service := newTranslationProvider(name)
+		if service == nil {
+			continue
+		}
+		fallback.AddProvider(name, withGlossary(service), circuitBreakerFromEnv())
+	}
+
+	if len(fallback.providers) == 0 {
+		log.Println("No translation API configured, using mock service")
+		fallback.AddProvider(ProviderMock, &mockTranslationService{}, circuitBreakerFromEnv())
+	}
+
+	currentService = fallback
+}
+
+// newTranslationProvider creates a provider by name, or returns nil if it is unknown
+// or not configured
+func newTranslationProvider(name string) TranslationService {
+	switch name {
+	case ProviderGoogle:
+		apiKey := GoogleTranslateAPIKey()
+		if apiKey == "" {
+			log.Println("Google Translate skipped: GOOGLE_TRANSLATE_API_KEY is not set")
+			return nil
+		}
 		log.Println("Using Google Translate for translations")
 		googleClient := NewGoogleTranslateClient(apiKey)
 
@@ -67,9 +138,13 @@ func InitTranslationService() {
 			log.Println("Redis cache enabled for translations")
 			googleClient.cache.EnableRedisCache()
 		}
-
-		currentService = googleClient
-	} else if apiKey := DeepLAPIKey(); apiKey != "" {
+		return googleClient
+	case ProviderDeepL:
+		apiKey := DeepLAPIKey()
+		if apiKey == "" {
+			log.Println("DeepL skipped: DEEPL_API_KEY is not set")
+			return nil
+		}
 		log.Println("Using DeepL for translations")
 		deepLClient := NewDeepLClient(apiKey)
 
@@ -78,31 +153,102 @@ func InitTranslationService() {
 			log.Println("Redis cache enabled for translations")
 			deepLClient.cache.EnableRedisCache()
 		}
+		return deepLClient
+	case ProviderLibreTranslate:
+		baseURL := LibreTranslateURL()
+		if baseURL == "" {
+			log.Println("LibreTranslate skipped: LIBRETRANSLATE_URL is not set")
+			return nil
+		}
+		log.Printf("Using LibreTranslate at %s for translations", baseURL)
+		libreClient := NewLibreTranslateClient(baseURL, LibreTranslateAPIKey())
 
-		currentService = deepLClient
-	} else {
-		log.Println("No translation API configured, using mock service")
-		currentService = &mockTranslationService{}
+		// Enable Redis cache if available
+		if cache.Client != nil {
+			log.Println("Redis cache enabled for translations")
+			libreClient.cache.EnableRedisCache()
+		}
+		return libreClient
+	case ProviderMock:
+		log.Println("Using mock service for translations")
+		return &mockTranslationService{}
+	default:
+		log.Printf("Unknown translation provider ignored: %s", name)
+		return nil
+	}
+}
+
+// TranslationStatus reports the health of the translation providers, in fallback order
+func TranslationStatus() []ProviderStatus {
+	if currentService == nil {
+		InitTranslationService()
 	}
+	if fallback, ok := currentService.(*FallbackTranslationService); ok {
+		return fallback.Status()
+	}
+	return []ProviderStatus{}
 }
 
-// TranslateText translates the text to the target language
-func TranslateText(text, targetLang string) (string, error) {
+// TranslateText translates the text to the target language, from the language it is
+// detected to be written in (English when it can't be told)
+func TranslateText(ctx context.Context, text, targetLang string) (string, error) {
 	if currentService == nil {
 		InitTranslationService()
 	}
 
-	// If the target language is English or empty, we do not translate
-	if targetLang == "" || targetLang == "en" {
+	sourceLang := SourceLanguage("", text)
+	// If the target language is empty or the text is already in it, we do not translate
+	if targetLang == "" || targetLang == sourceLang {
 		return text, nil
 	}
 
 	// Truncate very long text (just for logging, not for actual translation)
 	logText := truncateForLogging(text)
-	log.Printf("Translating text: '%s' to '%s'", logText, targetLang)
+	log.Printf("Translating text: '%s' from '%s' to '%s'", logText, sourceLang, targetLang)
+
+	return currentService.Translate(ctx, text, sourceLang, targetLang)
+}
+
+// TranslateTexts translates several texts written in the same language to the target
+// language in as few provider calls as possible
+func TranslateTexts(ctx context.Context, texts []string, targetLang string) ([]string, error) {
+	if len(texts) == 0 {
+		return texts, nil
+	}
+	return TranslateTextsFrom(ctx, texts, SourceLanguage("", texts...), targetLang)
+}
+
+// TranslateTextsFrom translates several texts from the source language to the target language
+func TranslateTextsFrom(ctx context.Context, texts []string, sourceLang, targetLang string) ([]string, error) {
+	if currentService == nil {
+		InitTranslationService()
+	}
+
+	// If the target language is empty or the texts are already in it, we do not translate
+	if targetLang == "" || targetLang == sourceLang || len(texts) == 0 {
+		return texts, nil
+	}
+
+	log.Printf("Translating %d texts from '%s' to '%s'", len(texts), sourceLang, targetLang)
+	return currentService.TranslateBatch(ctx, texts, sourceLang, targetLang)
+}
+
+// translateTextsWithProvider is TranslateTextsFrom, also returning the name of the provider
+// that translated each text (empty for the texts that were not translated, and no names at
+// all when the current service is not a fallback chain)
+func translateTextsWithProvider(ctx context.Context, texts []string, sourceLang, targetLang string) ([]string, []string, error) {
+	if currentService == nil {
+		InitTranslationService()
+	}
+
+	fallback, ok := currentService.(*FallbackTranslationService)
+	if !ok {
+		translated, err := TranslateTextsFrom(ctx, texts, sourceLang, targetLang)
+		return translated, nil, err
+	}
 
-	// Assume English as the source language
-	return currentService.Translate(text, "en", targetLang)
+	log.Printf("Translating %d texts from '%s' to '%s'", len(texts), sourceLang, targetLang)
+	return fallback.translateBatch(ctx, texts, sourceLang, targetLang)
 }
 
 // Helper method to truncate long text in logs
@@ -114,12 +260,12 @@ func truncateForLogging(text string) string {
 }
 
 // TryTranslate tries to translate a text, returning the original in case of error
-func TryTranslate(text string, targetLang string) string {
-	if targetLang == "en" || strings.TrimSpace(text) == "" {
+func TryTranslate(ctx context.Context, text string, targetLang string) string {
+	if strings.TrimSpace(text) == "" {
 		return text
 	}
 
-	translated, err := TranslateText(text, targetLang)
+	translated, err := TranslateText(ctx, text, targetLang)
 	if err != nil {
 		log.Printf("Error translating text: %v", err)
 		return text
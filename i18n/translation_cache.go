@@ -2,126 +2,178 @@ package i18n
 
 import (
 	"astrovista-api/cache"
+	"container/list"
 	"sync"
 	"time"
 )
 
 // TranslationCache implements an in-memory cache for translations
-// to reduce requests to the translation API
+// to reduce requests to the translation API. It holds up to maxSize entries and
+// evicts the least recently used one when full.
 type TranslationCache struct {
-	translations map[string]cacheEntry
-	mutex        sync.RWMutex
-	maxSize      int
-	expiration   time.Duration
-	// Optional functions for Redis integration
-	redisEnabled bool
+	mutex      sync.Mutex
+	entries    map[string]*list.Element
+	lru        *list.List // Most recently used at the front
+	maxSize    int
+	expiration time.Duration
+	// Optional Redis layer, shared by every provider
+	redis *RedisTranslationCache
+	// Lookup statistics
+	hits      int64
+	misses    int64
+	evictions int64
 }
 
 // cacheEntry represents a cache entry with expiration information
 type cacheEntry struct {
+	key        string
 	value      string
 	expiration time.Time
 }
 
+// TranslationCacheStats reports the size and effectiveness of a translation cache
+type TranslationCacheStats struct {
+	// Entries in memory
+	// example: 820
+	Size int `json:"size"`
+	// Maximum entries in memory
+	// example: 1000
+	MaxSize int `json:"max_size"`
+	// Lookups answered by the cache (memory or Redis)
+	// example: 1520
+	Hits int64 `json:"hits"`
+	// Lookups not found in the cache
+	// example: 180
+	Misses int64 `json:"misses"`
+	// Share of lookups answered by the cache (0 to 1)
+	// example: 0.89
+	HitRatio float64 `json:"hit_ratio"`
+	// Entries evicted to make room for new ones
+	// example: 12
+	Evictions int64 `json:"evictions"`
+	// Whether translations are also stored in Redis
+	Redis bool `json:"redis"`
+}
+
 // NewTranslationCache creates a new instance of the translation cache
 func NewTranslationCache() *TranslationCache {
+	return newTranslationCache(1000) // Limits the maximum number of entries
+}
+
+// newTranslationCache creates a cache holding up to maxSize entries
+func newTranslationCache(maxSize int) *TranslationCache {
 	return &TranslationCache{
-		translations: make(map[string]cacheEntry),
-		maxSize:      1000,           // Limits the maximum number of entries
-		expiration:   24 * time.Hour, // Default expiration time
-		redisEnabled: false,          // By default, does not use Redis
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		maxSize:    maxSize,
+		expiration: 24 * time.Hour, // Default expiration time
 	}
 }
 
 // EnableRedisCache configures the cache to also use Redis
 func (c *TranslationCache) EnableRedisCache() {
-	c.redisEnabled = cache.Client != nil
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if cache.Client != nil && c.redis == nil {
+		c.redis = NewRedisTranslationCache(cache.Client)
+	}
 }
 
-// Get retrieves a translation from the cache
+// Get retrieves a translation from memory, then from Redis if enabled
 func (c *TranslationCache) Get(key string) (string, bool) {
-	// If Redis is enabled, try to fetch from Redis cache first
-	if c.redisEnabled && cache.Client != nil {
-		redisCache := NewRedisTranslationCache()
-		if value, found := redisCache.Get(key); found {
+	c.mutex.Lock()
+	if element, found := c.entries[key]; found {
+		entry := element.Value.(*cacheEntry)
+		if time.Now().Before(entry.expiration) {
+			c.lru.MoveToFront(element)
+			c.hits++
+			c.mutex.Unlock()
+			return entry.value, true
+		}
+		c.removeLocked(element)
+	}
+	redis := c.redis
+	c.mutex.Unlock()
+
+	if redis != nil {
+		if value, found := redis.Get(key); found {
+			// Keep it in memory for the next lookups
+			c.mutex.Lock()
+			c.hits++
+			c.setLocked(key, value)
+			c.mutex.Unlock()
 			return value, true
 		}
 	}
 
-	// Otherwise, use the in-memory cache
-	c.mutex.RLock()
-	defer c.mutex.RUnlock()
+	c.mutex.Lock()
+	c.misses++
+	c.mutex.Unlock()
+	return "", false
+}
 
-	entry, found := c.translations[key]
-	if !found {
-		return "", false
-	}
+// Set stores a translation in memory, and in Redis if enabled
+func (c *TranslationCache) Set(key string, value string) {
+	c.mutex.Lock()
+	c.setLocked(key, value)
+	redis := c.redis
+	c.mutex.Unlock()
 
-	// Check if the entry has expired
-	if time.Now().After(entry.expiration) {
-		// Could do the removal here, but to avoid
-		// lock upgrading, we leave it to the periodic cleanup process
-		return "", false
+	if redis != nil {
+		redis.Set(key, value)
 	}
-
-	return entry.value, true
 }
 
-// Set stores a translation in the cache
-func (c *TranslationCache) Set(key string, value string) {
-	// If Redis is enabled, also store in Redis
-	if c.redisEnabled && cache.Client != nil {
-		redisCache := NewRedisTranslationCache()
-		redisCache.Set(key, value)
+// setLocked stores an entry in memory, evicting the least recently used ones if the
+// cache is full (assumes the lock is already obtained)
+func (c *TranslationCache) setLocked(key, value string) {
+	expiration := time.Now().Add(c.expiration)
+	if element, found := c.entries[key]; found {
+		entry := element.Value.(*cacheEntry)
+		entry.value = value
+		entry.expiration = expiration
+		c.lru.MoveToFront(element)
+		return
 	}
 
-	// Also store in the in-memory cache for quick access
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-
-	// If the cache is full and the key doesn't exist, do a cleanup
-	if len(c.translations) >= c.maxSize && c.translations[key].value == "" {
-		c.cleanupLocked()
+	c.entries[key] = c.lru.PushFront(&cacheEntry{key: key, value: value, expiration: expiration})
+	for c.lru.Len() > c.maxSize {
+		c.removeLocked(c.lru.Back())
+		c.evictions++
 	}
+}
 
-	c.translations[key] = cacheEntry{
-		value:      value,
-		expiration: time.Now().Add(c.expiration),
-	}
+// removeLocked removes an entry from memory (assumes the lock is already obtained)
+func (c *TranslationCache) removeLocked(element *list.Element) {
+	c.lru.Remove(element)
+	delete(c.entries, element.Value.(*cacheEntry).key)
 }
 
-// Clear clears the entire cache
+// Clear clears the in-memory cache
 func (c *TranslationCache) Clear() {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	c.translations = make(map[string]cacheEntry)
+	c.entries = make(map[string]*list.Element)
+	c.lru.Init()
 }
 
-// cleanupLocked removes expired entries from the cache (assumes the lock is already obtained)
-func (c *TranslationCache) cleanupLocked() {
-	now := time.Now()
+// Stats returns the size and hit/miss statistics of the cache
+func (c *TranslationCache) Stats() TranslationCacheStats {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
 
-	// Remove expired entries
-	for key, entry := range c.translations {
-		if now.After(entry.expiration) {
-			delete(c.translations, key)
-		}
+	stats := TranslationCacheStats{
+		Size:      c.lru.Len(),
+		MaxSize:   c.maxSize,
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+		Redis:     c.redis != nil,
 	}
-
-	// If it's still too large, remove the oldest ones
-	// This is a simple implementation, not a complete LRU
-	if len(c.translations) >= c.maxSize {
-		// We remove about 25% of the cache to avoid doing this frequently
-		toRemove := c.maxSize / 4
-		removed := 0
-
-		for key := range c.translations {
-			delete(c.translations, key)
-			removed++
-			if removed >= toRemove {
-				break
-			}
-		}
+	if lookups := c.hits + c.misses; lookups > 0 {
+		stats.HitRatio = float64(c.hits) / float64(lookups)
 	}
+	return stats
 }
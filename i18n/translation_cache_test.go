@@ -0,0 +1,86 @@
+package i18n
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// TestTranslationCacheLRU checks that the least recently used entry is evicted and the statistics
+func TestTranslationCacheLRU(t *testing.T) {
+	cache := newTranslationCache(2)
+	cache.Set("a", "1")
+	cache.Set("b", "2")
+
+	// Reading "a" makes "b" the least recently used entry
+	if _, found := cache.Get("a"); !found {
+		t.Fatal("Expected a to be cached")
+	}
+	cache.Set("c", "3")
+
+	if _, found := cache.Get("b"); found {
+		t.Error("Expected b to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, found := cache.Get(key); !found {
+			t.Errorf("Expected %s to be cached", key)
+		}
+	}
+
+	stats := cache.Stats()
+	expected := TranslationCacheStats{Size: 2, MaxSize: 2, Hits: 3, Misses: 1, HitRatio: 0.75, Evictions: 1}
+	if stats != expected {
+		t.Errorf("Expected %+v, got %+v", expected, stats)
+	}
+}
+
+// TestTranslationCacheKey checks that long texts sharing their beginning, end and length
+// don't collide, and that the key changes with the provider and the glossary version
+func TestTranslationCacheKey(t *testing.T) {
+	prefix := strings.Repeat("The Andromeda Galaxy ", 2)
+	suffix := strings.Repeat(" seen from Earth", 2)
+	first := prefix + "is a spiral galaxy" + suffix
+	second := prefix + "is a barred galaxy" + suffix
+
+	if translationCacheKey(ProviderGoogle, "en", "pt-BR", "", first) == translationCacheKey(ProviderGoogle, "en", "pt-BR", "", second) {
+		t.Error("Expected distinct texts to have distinct keys")
+	}
+	if translationCacheKey(ProviderGoogle, "en", "pt-BR", "", first) == translationCacheKey(ProviderDeepL, "en", "pt-BR", "", first) {
+		t.Error("Expected providers to have distinct keys")
+	}
+	if translationCacheKey(ProviderGoogle, "en", "pt-BR", "v1", first) == translationCacheKey(ProviderGoogle, "en", "pt-BR", "v2", first) {
+		t.Error("Expected glossary versions to have distinct keys")
+	}
+}
+
+// TestBatchTranslateGlossaryChange checks that a glossary change invalidates cached translations
+func TestBatchTranslateGlossaryChange(t *testing.T) {
+	SetGlossary(NewGlossary(nil))
+	defer SetGlossary(NewGlossary(nil))
+
+	calls := 0
+	translateChunk := func(ctx context.Context, chunk []string, format textFormat) ([]string, error) {
+		calls++
+		return chunk, nil
+	}
+	cache := NewTranslationCache()
+	limits := batchLimits{maxItems: 10, maxChars: 100}
+	texts := []string{"The Andromeda Galaxy"}
+
+	for i := 0; i < 2; i++ {
+		if _, err := batchTranslate(context.Background(), cache, "test", texts, "en", "pt-BR", limits, translateChunk); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("Expected the second batch to be cached, got %d calls", calls)
+	}
+
+	SetGlossary(NewGlossary([]GlossaryEntry{{Term: "Andromeda"}}))
+	if _, err := batchTranslate(context.Background(), cache, "test", texts, "en", "pt-BR", limits, translateChunk); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected the text to be translated again after the glossary change, got %d calls", calls)
+	}
+}
@@ -0,0 +1,298 @@
+package i18n
+
+import (
+	"astrovista-api/database"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Translation sources
+const (
+	SourceMachine = "machine" // Produced by a translation provider
+	SourceHuman   = "human"   // Written or corrected by an editor and approved
+)
+
+// Review states of a human translation
+const (
+	ReviewDraft    = "draft"    // Edited, waiting for approval
+	ReviewApproved = "approved" // Approved and served to readers
+)
+
+var (
+	// ErrTranslationNotFound is returned when no translation exists for a field
+	ErrTranslationNotFound = errors.New("translation not found")
+	// ErrNoDraft is returned when approving a field without a pending draft
+	ErrNoDraft = errors.New("no draft translation to approve")
+)
+
+// TranslationReview is the editorial state of a human translation
+type TranslationReview struct {
+	// Text written by the editor
+	Text string `bson:"text" json:"text"`
+	// Review state (draft or approved)
+	Status string `bson:"status" json:"status"`
+	// Who last edited the text
+	Editor    string    `bson:"editor" json:"editor"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+	// Who approved the text
+	ApprovedBy string     `bson:"approved_by,omitempty" json:"approved_by,omitempty"`
+	ApprovedAt *time.Time `bson:"approved_at,omitempty" json:"approved_at,omitempty"`
+}
+
+// StoredTranslation is the permanent translation of one APOD field into one language
+type StoredTranslation struct {
+	ID primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	// APOD date the translation belongs to
+	Date string `bson:"date" json:"date"`
+	// Target language
+	Lang string `bson:"lang" json:"lang"`
+	// Translated field (title, explanation or copyright)
+	Field string `bson:"field" json:"field"`
+	// Translated text
+	Text string `bson:"text" json:"text"`
+	// SHA-256 of the English text, so edits to the APOD invalidate the translation
+	SourceHash string `bson:"source_hash" json:"source_hash"`
+	// Where the served text comes from (machine or human)
+	Source string `bson:"source" json:"source"`
+	// Provider that produced a machine translation
+	Provider string `bson:"provider,omitempty" json:"provider,omitempty"`
+	// Version of the glossary the machine translation was made with, empty when no
+	// glossary term applied, so glossary changes invalidate the translation
+	GlossaryVersion string `bson:"glossary_version,omitempty" json:"glossary_version,omitempty"`
+	// Machine translation replaced by an approved human translation
+	MachineText string `bson:"machine_text,omitempty" json:"machine_text,omitempty"`
+	// Editorial state, if an editor worked on this field
+	Review    *TranslationReview `bson:"review,omitempty" json:"review,omitempty"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// storeTimeout bounds background writes to the translation store
+const storeTimeout = 10 * time.Second
+
+// EnsureTranslationIndexes creates the indexes used by translation lookups
+func EnsureTranslationIndexes(ctx context.Context) error {
+	if database.TranslationCollection == nil {
+		return nil
+	}
+	_, err := database.TranslationCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "date", Value: 1}, {Key: "lang", Value: 1}, {Key: "field", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+// FindStoredTranslations returns the stored translations of the given APOD dates into a
+// language, keyed by storedTranslationKey
+func FindStoredTranslations(ctx context.Context, dates []string, lang string) (map[string]StoredTranslation, error) {
+	translations := make(map[string]StoredTranslation)
+	if database.TranslationCollection == nil || len(dates) == 0 {
+		return translations, nil
+	}
+
+	cursor, err := database.TranslationCollection.Find(ctx, bson.M{
+		"date": bson.M{"$in": dates},
+		"lang": lang,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []StoredTranslation
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	for _, translation := range results {
+		translations[storedTranslationKey(translation.Date, translation.Field)] = translation
+	}
+	return translations, nil
+}
+
+// Servable reports whether the stored text can be served for the given original text,
+// written in sourceLang. Approved human translations always win; machine translations
+// must match the source and the current glossary.
+func (t StoredTranslation) Servable(sourceText, sourceLang string) bool {
+	if t.Source == SourceHuman {
+		return t.Text != ""
+	}
+	return t.Text != "" && t.SourceHash == sourceHash(sourceText) &&
+		t.GlossaryVersion == cacheGlossaryVersion(sourceLang, t.Lang)
+}
+
+// SaveMachineTranslations upserts machine translations. Approved human translations are never overwritten.
+func SaveMachineTranslations(ctx context.Context, translations []StoredTranslation) error {
+	if database.TranslationCollection == nil || len(translations) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	models := make([]mongo.WriteModel, 0, len(translations))
+	for _, translation := range translations {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{
+				"date":   translation.Date,
+				"lang":   translation.Lang,
+				"field":  translation.Field,
+				"source": bson.M{"$ne": SourceHuman},
+			}).
+			SetUpdate(bson.M{
+				"$set": bson.M{
+					"text":             translation.Text,
+					"source_hash":      translation.SourceHash,
+					"source":           SourceMachine,
+					"provider":         translation.Provider,
+					"glossary_version": translation.GlossaryVersion,
+					"updated_at":       now,
+				},
+				"$setOnInsert": bson.M{"created_at": now},
+			}).
+			SetUpsert(true))
+	}
+
+	_, err := database.TranslationCollection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	return ignoreDuplicateWrites(err)
+}
+
+// duplicateKeyCode is the MongoDB error code of a duplicate key
+const duplicateKeyCode = 11000
+
+// ignoreDuplicateWrites drops the duplicate key errors of an unordered bulk write, which
+// come from upserts hitting a human translation that is kept as-is. Any other error,
+// including the other writes of the same bulk write, is returned.
+func ignoreDuplicateWrites(err error) error {
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) {
+		return err
+	}
+
+	remaining := bulkErr
+	remaining.WriteErrors = nil
+	for _, writeErr := range bulkErr.WriteErrors {
+		if writeErr.Code != duplicateKeyCode {
+			remaining.WriteErrors = append(remaining.WriteErrors, writeErr)
+		}
+	}
+	if len(remaining.WriteErrors) == 0 && remaining.WriteConcernError == nil {
+		return nil
+	}
+	return remaining
+}
+
+// FindAPODTranslations returns every stored translation of an APOD into a language
+func FindAPODTranslations(ctx context.Context, date, lang string) ([]StoredTranslation, error) {
+	cursor, err := database.TranslationCollection.Find(ctx, bson.M{"date": date, "lang": lang})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	translations := []StoredTranslation{}
+	if err := cursor.All(ctx, &translations); err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+// SaveTranslationDraft stores an editor's text for a field as a draft. The served text
+// does not change until the draft is approved.
+func SaveTranslationDraft(ctx context.Context, date, lang, field, text, editor string) (*StoredTranslation, error) {
+	now := time.Now().UTC()
+	var translation StoredTranslation
+	err := database.TranslationCollection.FindOneAndUpdate(ctx,
+		bson.M{"date": date, "lang": lang, "field": field},
+		bson.M{
+			"$set": bson.M{
+				"review": TranslationReview{
+					Text:      text,
+					Status:    ReviewDraft,
+					Editor:    editor,
+					UpdatedAt: now,
+				},
+				"updated_at": now,
+			},
+			"$setOnInsert": bson.M{"source": SourceMachine, "created_at": now},
+		},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&translation)
+	if err != nil {
+		return nil, err
+	}
+	return &translation, nil
+}
+
+// ApproveTranslationDraft makes the pending draft of a field the served translation
+func ApproveTranslationDraft(ctx context.Context, date, lang, field, approver string) (*StoredTranslation, error) {
+	filter := bson.M{"date": date, "lang": lang, "field": field}
+
+	var current StoredTranslation
+	if err := database.TranslationCollection.FindOne(ctx, filter).Decode(&current); err == mongo.ErrNoDocuments {
+		return nil, ErrTranslationNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	if current.Review == nil || current.Review.Status != ReviewDraft {
+		return nil, ErrNoDraft
+	}
+
+	now := time.Now().UTC()
+	set := bson.M{
+		"text":               current.Review.Text,
+		"source":             SourceHuman,
+		"review.status":      ReviewApproved,
+		"review.approved_by": approver,
+		"review.approved_at": now,
+		"updated_at":         now,
+	}
+	if current.Source == SourceMachine && current.Text != "" {
+		// Keep the machine output for reference
+		set["machine_text"] = current.Text
+	}
+
+	// Only approve the draft that was read, not one saved in the meantime
+	filter["review.updated_at"] = current.Review.UpdatedAt
+	var translation StoredTranslation
+	err := database.TranslationCollection.FindOneAndUpdate(ctx, filter, bson.M{"$set": set},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&translation)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrNoDraft
+	} else if err != nil {
+		return nil, err
+	}
+	return &translation, nil
+}
+
+// saveMachineTranslationsAsync stores translations in the background so requests don't wait for MongoDB
+func saveMachineTranslationsAsync(translations []StoredTranslation) {
+	if database.TranslationCollection == nil || len(translations) == 0 {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+		defer cancel()
+		if err := SaveMachineTranslations(ctx, translations); err != nil {
+			log.Printf("Error storing translations: %v", err)
+		}
+	}()
+}
+
+// storedTranslationKey identifies a field of an APOD
+func storedTranslationKey(date, field string) string {
+	return date + "|" + field
+}
+
+// sourceHash fingerprints the English text a translation was made from
+func sourceHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
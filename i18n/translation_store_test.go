@@ -0,0 +1,70 @@
+package i18n
+
+import (
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// TestStoredTranslationServable checks that human translations win and stale machine ones are ignored
+func TestStoredTranslationServable(t *testing.T) {
+	english := "Andromeda Galaxy"
+
+	machine := StoredTranslation{Text: "Galáxia de Andrômeda", Source: SourceMachine, SourceHash: sourceHash(english)}
+	if !machine.Servable(english, "en") {
+		t.Error("A machine translation of the current text should be served")
+	}
+	if machine.Servable("Andromeda Galaxy in Infrared", "en") {
+		t.Error("A machine translation of an older text should not be served")
+	}
+
+	human := StoredTranslation{Text: "Galáxia de Andrômeda (M31)", Source: SourceHuman, SourceHash: sourceHash("old text")}
+	if !human.Servable(english, "en") {
+		t.Error("An approved human translation should always be served")
+	}
+
+	draftOnly := StoredTranslation{Source: SourceMachine, Review: &TranslationReview{Text: "Rascunho", Status: ReviewDraft}}
+	if draftOnly.Servable(english, "en") {
+		t.Error("A draft without an approved or machine text should not be served")
+	}
+}
+
+// TestIgnoreDuplicateWrites checks that only the duplicate key errors of a bulk write are ignored
+func TestIgnoreDuplicateWrites(t *testing.T) {
+	duplicate := mongo.BulkWriteError{WriteError: mongo.WriteError{Index: 0, Code: duplicateKeyCode, Message: "E11000 duplicate key"}}
+	validation := mongo.BulkWriteError{WriteError: mongo.WriteError{Index: 1, Code: 121, Message: "Document failed validation"}}
+	network := errors.New("connection reset")
+
+	testCases := []struct {
+		name        string
+		err         error
+		expectError bool
+	}{
+		{"No error", nil, false},
+		{"Only duplicates", mongo.BulkWriteException{WriteErrors: []mongo.BulkWriteError{duplicate, duplicate}}, false},
+		{"Duplicate and validation error", mongo.BulkWriteException{WriteErrors: []mongo.BulkWriteError{duplicate, validation}}, true},
+		{"Duplicate and write concern error", mongo.BulkWriteException{
+			WriteErrors:       []mongo.BulkWriteError{duplicate},
+			WriteConcernError: &mongo.WriteConcernError{Code: 64, Message: "waiting for replication timed out"},
+		}, true},
+		{"Network error", network, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ignoreDuplicateWrites(tc.err)
+			if (err != nil) != tc.expectError {
+				t.Fatalf("Expected error: %v, got %v", tc.expectError, err)
+			}
+			var bulkErr mongo.BulkWriteException
+			if errors.As(err, &bulkErr) {
+				for _, writeErr := range bulkErr.WriteErrors {
+					if writeErr.Code == duplicateKeyCode {
+						t.Errorf("Duplicate key errors should be dropped, got %v", bulkErr.WriteErrors)
+					}
+				}
+			}
+		})
+	}
+}
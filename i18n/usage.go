@@ -0,0 +1,433 @@
+package i18n
+
+import (
+	"astrovista-api/cache"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// ErrTranslationBudgetExceeded is returned when translating would exceed a monthly character budget
+var ErrTranslationBudgetExceeded = errors.New("translation budget exceeded")
+
+// Budget scopes
+const (
+	BudgetScopeTotal    = "total"    // Every provider and language
+	BudgetScopeProvider = "provider" // One provider
+	BudgetScopeLanguage = "lang"     // One target language
+)
+
+// usageTimeout bounds the usage store calls made while translating
+const usageTimeout = 2 * time.Second
+
+// TranslationUsage is what one provider translated into one language on one day
+type TranslationUsage struct {
+	// Day (UTC)
+	// example: 2025-01-15
+	Date string `json:"date"`
+	// example: deepl
+	Provider string `json:"provider"`
+	// example: pt-BR
+	Lang string `json:"lang"`
+	// Characters sent to the provider
+	// example: 18250
+	Characters int64 `json:"characters"`
+	// Texts answered by the translation cache
+	// example: 120
+	CacheHits int64 `json:"cache_hits"`
+	// Texts missing from the translation cache
+	// example: 14
+	CacheMisses int64 `json:"cache_misses"`
+}
+
+// TranslationUsageTotals sums the usage of a report
+type TranslationUsageTotals struct {
+	Characters  int64 `json:"characters"`
+	CacheHits   int64 `json:"cache_hits"`
+	CacheMisses int64 `json:"cache_misses"`
+	// Share of texts answered by the cache (0 to 1)
+	// example: 0.9
+	CacheHitRatio float64 `json:"cache_hit_ratio"`
+}
+
+// TranslationBudgetStatus reports the consumption of a monthly character budget
+type TranslationBudgetStatus struct {
+	// Budget scope (total, provider or lang)
+	// example: provider
+	Scope string `json:"scope"`
+	// Provider or language the budget applies to
+	// example: deepl
+	Name string `json:"name,omitempty"`
+	// Characters allowed per month
+	// example: 500000
+	Limit int64 `json:"limit"`
+	// Characters used this month
+	// example: 123456
+	Used int64 `json:"used"`
+	// example: 376544
+	Remaining int64 `json:"remaining"`
+	// Whether translations in this scope are suspended until next month
+	Exceeded bool `json:"exceeded"`
+}
+
+// TranslationUsageReport is the translation usage over a period of days
+type TranslationUsageReport struct {
+	// First day of the report (UTC)
+	// example: 2025-01-01
+	From string `json:"from"`
+	// Last day of the report (UTC)
+	// example: 2025-01-31
+	To string `json:"to"`
+	// Usage per day, provider and language
+	Usage  []TranslationUsage     `json:"usage"`
+	Totals TranslationUsageTotals `json:"totals"`
+	// Budgets of the current month
+	Budgets []TranslationBudgetStatus `json:"budgets"`
+}
+
+// translationBudget is a monthly character budget
+type translationBudget struct {
+	scope string
+	name  string
+	limit int64
+}
+
+// field returns the field of the monthly usage hash the budget is checked against
+func (b translationBudget) field() string {
+	if b.scope == BudgetScopeTotal {
+		return BudgetScopeTotal
+	}
+	return b.scope + ":" + b.name
+}
+
+// usageStore keeps the usage counters as hashes of named counters
+type usageStore interface {
+	// increment atomically adds to the counters and returns their new values
+	increment(ctx context.Context, key string, counters map[string]int64, ttl time.Duration) (map[string]int64, error)
+	get(ctx context.Context, key string) (map[string]int64, error)
+}
+
+// redisUsageStore keeps the counters in Redis, shared by every instance
+type redisUsageStore struct{}
+
+func (redisUsageStore) increment(ctx context.Context, key string, counters map[string]int64, ttl time.Duration) (map[string]int64, error) {
+	pipe := cache.Client.TxPipeline()
+	results := make(map[string]*redis.IntCmd, len(counters))
+	for field, value := range counters {
+		results[field] = pipe.HIncrBy(ctx, key, field, value)
+	}
+	pipe.Expire(ctx, key, ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+	values := make(map[string]int64, len(results))
+	for field, result := range results {
+		values[field] = result.Val()
+	}
+	return values, nil
+}
+
+func (redisUsageStore) get(ctx context.Context, key string) (map[string]int64, error) {
+	values, err := cache.Client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+	counters := make(map[string]int64, len(values))
+	for field, value := range values {
+		n, _ := strconv.ParseInt(value, 10, 64)
+		counters[field] = n
+	}
+	return counters, nil
+}
+
+// memoryUsageStore keeps the counters in the process when Redis is unavailable
+type memoryUsageStore struct {
+	mutex  sync.Mutex
+	hashes map[string]map[string]int64
+}
+
+func newMemoryUsageStore() *memoryUsageStore {
+	return &memoryUsageStore{hashes: make(map[string]map[string]int64)}
+}
+
+func (s *memoryUsageStore) increment(ctx context.Context, key string, counters map[string]int64, ttl time.Duration) (map[string]int64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	hash, ok := s.hashes[key]
+	if !ok {
+		hash = make(map[string]int64)
+		s.hashes[key] = hash
+	}
+	values := make(map[string]int64, len(counters))
+	for field, value := range counters {
+		hash[field] += value
+		values[field] = hash[field]
+	}
+	return values, nil
+}
+
+func (s *memoryUsageStore) get(ctx context.Context, key string) (map[string]int64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	counters := make(map[string]int64, len(s.hashes[key]))
+	for field, value := range s.hashes[key] {
+		counters[field] = value
+	}
+	return counters, nil
+}
+
+// Usage accounting state, set up by InitTranslationService
+var (
+	usageMutex         sync.RWMutex
+	usage              usageStore = newMemoryUsageStore()
+	translationBudgets []translationBudget
+	// usageNow is replaced in tests
+	usageNow = time.Now
+)
+
+// initTranslationUsage selects the usage store and reads the budgets from TRANSLATION_BUDGETS
+func initTranslationUsage() {
+	usageMutex.Lock()
+	defer usageMutex.Unlock()
+
+	if cache.Client != nil {
+		usage = redisUsageStore{}
+	} else {
+		usage = newMemoryUsageStore()
+	}
+	translationBudgets = parseTranslationBudgets(os.Getenv("TRANSLATION_BUDGETS"))
+}
+
+// parseTranslationBudgets parses budgets like "total=2000000,deepl=500000,pt-BR=300000".
+// Each name is a provider, a supported language or "total".
+func parseTranslationBudgets(value string) []translationBudget {
+	var budgets []translationBudget
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, limitValue, _ := strings.Cut(item, "=")
+		name = strings.TrimSpace(name)
+		limit, err := strconv.ParseInt(strings.TrimSpace(limitValue), 10, 64)
+		if err != nil || limit < 0 {
+			log.Printf("Invalid value for TRANSLATION_BUDGETS ignored: %s (expected <name>=<characters>)", item)
+			continue
+		}
+
+		switch {
+		case name == BudgetScopeTotal:
+			budgets = append(budgets, translationBudget{scope: BudgetScopeTotal, limit: limit})
+		case isTranslationProvider(strings.ToLower(name)):
+			budgets = append(budgets, translationBudget{scope: BudgetScopeProvider, name: strings.ToLower(name), limit: limit})
+		case IsSupportedLanguage(name):
+			budgets = append(budgets, translationBudget{scope: BudgetScopeLanguage, name: name, limit: limit})
+		default:
+			log.Printf("Invalid value for TRANSLATION_BUDGETS ignored: %s (unknown provider or language)", item)
+		}
+	}
+	return budgets
+}
+
+// isTranslationProvider reports whether a name is a known translation provider
+func isTranslationProvider(name string) bool {
+	switch name {
+	case ProviderGoogle, ProviderDeepL, ProviderLibreTranslate, ProviderMock:
+		return true
+	}
+	return false
+}
+
+// usageDayKey and usageMonthKey are the keys of the daily and monthly usage hashes
+func usageDayKey(day time.Time) string {
+	return "translation_usage:day:" + day.UTC().Format("2006-01-02")
+}
+
+func usageMonthKey(day time.Time) string {
+	return "translation_usage:month:" + day.UTC().Format("2006-01")
+}
+
+// usageDayField names a counter of the daily hash
+func usageDayField(provider, lang, counter string) string {
+	return provider + "|" + lang + "|" + counter
+}
+
+// usageTTL is how long the usage hashes are kept, so last year's reports still work
+const usageTTL = 400 * 24 * time.Hour
+
+// monthlyUsageCounters are the fields of the monthly hash a provider call is counted in
+func monthlyUsageCounters(provider, lang string, characters int64) map[string]int64 {
+	return map[string]int64{
+		BudgetScopeTotal:                     characters,
+		BudgetScopeProvider + ":" + provider: characters,
+		BudgetScopeLanguage + ":" + lang:     characters,
+	}
+}
+
+// reserveTranslationBudget counts the characters about to be sent to the provider against
+// this month's usage before the call, so concurrent requests can't all pass the check and
+// overshoot a budget together. When a budget would be exceeded the reservation is rolled
+// back and ErrTranslationBudgetExceeded is returned. Otherwise settle must be called with
+// the characters actually sent, which gives back the unused part of the reservation.
+func reserveTranslationBudget(ctx context.Context, provider, lang string, characters int64) (settle func(sent int64), err error) {
+	usageMutex.RLock()
+	store, budgets := usage, translationBudgets
+	usageMutex.RUnlock()
+
+	reserved := int64(0)
+	settle = func(sent int64) {
+		if sent == reserved {
+			return
+		}
+		// The request may be over, the usage must be counted anyway
+		ctx, cancel := context.WithTimeout(context.Background(), usageTimeout)
+		defer cancel()
+		if _, err := store.increment(ctx, usageMonthKey(usageNow()), monthlyUsageCounters(provider, lang, sent-reserved), usageTTL); err != nil {
+			log.Printf("Error recording translation usage: %v", err)
+		}
+	}
+	if characters == 0 {
+		return settle, nil
+	}
+
+	reserveCtx, cancel := context.WithTimeout(ctx, usageTimeout)
+	defer cancel()
+	monthKey := usageMonthKey(usageNow())
+	month, err := store.increment(reserveCtx, monthKey, monthlyUsageCounters(provider, lang, characters), usageTTL)
+	if err != nil {
+		// Fail open: an unavailable store should not stop translations
+		log.Printf("Error reserving translation usage: %v", err)
+		return settle, nil
+	}
+	reserved = characters
+
+	for _, budget := range budgets {
+		if (budget.scope == BudgetScopeProvider && budget.name != provider) ||
+			(budget.scope == BudgetScopeLanguage && budget.name != lang) {
+			continue
+		}
+		if month[budget.field()] > budget.limit {
+			settle(0) // Roll the reservation back
+			return nil, fmt.Errorf("%w: %s budget of %d characters", ErrTranslationBudgetExceeded, budget.field(), budget.limit)
+		}
+	}
+	return settle, nil
+}
+
+// recordTranslationUsage counts the characters sent to a provider and the cache lookups
+// made for one target language in the daily usage. The monthly usage is counted by
+// reserveTranslationBudget.
+func recordTranslationUsage(provider, lang string, characters, hits, misses int64) {
+	if characters == 0 && hits == 0 && misses == 0 {
+		return
+	}
+	usageMutex.RLock()
+	store := usage
+	usageMutex.RUnlock()
+
+	// The request may be over, the usage must be counted anyway
+	ctx, cancel := context.WithTimeout(context.Background(), usageTimeout)
+	defer cancel()
+
+	_, err := store.increment(ctx, usageDayKey(usageNow()), map[string]int64{
+		usageDayField(provider, lang, "chars"):  characters,
+		usageDayField(provider, lang, "hits"):   hits,
+		usageDayField(provider, lang, "misses"): misses,
+	}, usageTTL)
+	if err != nil {
+		log.Printf("Error recording translation usage: %v", err)
+	}
+}
+
+// GetTranslationUsageReport returns the usage of every provider and language between two
+// days (inclusive) and the state of this month's budgets
+func GetTranslationUsageReport(ctx context.Context, from, to time.Time) (*TranslationUsageReport, error) {
+	usageMutex.RLock()
+	store, budgets := usage, translationBudgets
+	usageMutex.RUnlock()
+
+	report := &TranslationUsageReport{
+		From:    from.UTC().Format("2006-01-02"),
+		To:      to.UTC().Format("2006-01-02"),
+		Usage:   []TranslationUsage{},
+		Budgets: []TranslationBudgetStatus{},
+	}
+
+	for day := from.UTC(); !day.After(to.UTC()); day = day.AddDate(0, 0, 1) {
+		counters, err := store.get(ctx, usageDayKey(day))
+		if err != nil {
+			return nil, err
+		}
+
+		byTarget := make(map[string]*TranslationUsage)
+		for field, value := range counters {
+			parts := strings.Split(field, "|")
+			if len(parts) != 3 {
+				continue
+			}
+			entry, ok := byTarget[parts[0]+"|"+parts[1]]
+			if !ok {
+				entry = &TranslationUsage{Date: day.Format("2006-01-02"), Provider: parts[0], Lang: parts[1]}
+				byTarget[parts[0]+"|"+parts[1]] = entry
+			}
+			switch parts[2] {
+			case "chars":
+				entry.Characters = value
+			case "hits":
+				entry.CacheHits = value
+			case "misses":
+				entry.CacheMisses = value
+			}
+		}
+
+		dayUsage := make([]TranslationUsage, 0, len(byTarget))
+		for _, entry := range byTarget {
+			dayUsage = append(dayUsage, *entry)
+		}
+		sort.Slice(dayUsage, func(i, j int) bool {
+			if dayUsage[i].Provider != dayUsage[j].Provider {
+				return dayUsage[i].Provider < dayUsage[j].Provider
+			}
+			return dayUsage[i].Lang < dayUsage[j].Lang
+		})
+		for _, entry := range dayUsage {
+			report.Totals.Characters += entry.Characters
+			report.Totals.CacheHits += entry.CacheHits
+			report.Totals.CacheMisses += entry.CacheMisses
+		}
+		report.Usage = append(report.Usage, dayUsage...)
+	}
+	if lookups := report.Totals.CacheHits + report.Totals.CacheMisses; lookups > 0 {
+		report.Totals.CacheHitRatio = float64(report.Totals.CacheHits) / float64(lookups)
+	}
+
+	if len(budgets) > 0 {
+		month, err := store.get(ctx, usageMonthKey(usageNow()))
+		if err != nil {
+			return nil, err
+		}
+		for _, budget := range budgets {
+			used := month[budget.field()]
+			report.Budgets = append(report.Budgets, TranslationBudgetStatus{
+				Scope:     budget.scope,
+				Name:      budget.name,
+				Limit:     budget.limit,
+				Used:      used,
+				Remaining: max(budget.limit-used, 0),
+				Exceeded:  used >= budget.limit,
+			})
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,184 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// useTestUsage replaces the usage store and budgets for the duration of a test
+func useTestUsage(t *testing.T, budgets string) {
+	t.Helper()
+	previousStore, previousBudgets, previousNow := usage, translationBudgets, usageNow
+	t.Cleanup(func() {
+		usage, translationBudgets, usageNow = previousStore, previousBudgets, previousNow
+	})
+
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	usage = newMemoryUsageStore()
+	translationBudgets = parseTranslationBudgets(budgets)
+	usageNow = func() time.Time { return now }
+}
+
+// budgetExceededService always reports a spent budget
+type budgetExceededService struct{}
+
+func (budgetExceededService) Translate(ctx context.Context, text, sourceLang, targetLang string) (string, error) {
+	return "", ErrTranslationBudgetExceeded
+}
+
+func (budgetExceededService) TranslateBatch(ctx context.Context, texts []string, sourceLang, targetLang string) ([]string, error) {
+	return texts, ErrTranslationBudgetExceeded
+}
+
+// TestParseTranslationBudgets checks the budget scopes and that invalid entries are ignored
+func TestParseTranslationBudgets(t *testing.T) {
+	budgets := parseTranslationBudgets("total=1000, DeepL=500 ,pt-BR=200,xx=5,google=abc,")
+	expected := []translationBudget{
+		{scope: BudgetScopeTotal, limit: 1000},
+		{scope: BudgetScopeProvider, name: ProviderDeepL, limit: 500},
+		{scope: BudgetScopeLanguage, name: "pt-BR", limit: 200},
+	}
+	if len(budgets) != len(expected) {
+		t.Fatalf("Expected %d budgets, got %+v", len(expected), budgets)
+	}
+	for i := range expected {
+		if budgets[i] != expected[i] {
+			t.Errorf("Budget %d: expected %+v, got %+v", i, expected[i], budgets[i])
+		}
+	}
+}
+
+// TestTranslationUsageAndBudget checks the accounting of batchTranslate and that a spent
+// budget stops calls to the provider
+func TestTranslationUsageAndBudget(t *testing.T) {
+	useTestUsage(t, "deepl=10")
+
+	calls := 0
+	translateChunk := func(ctx context.Context, chunk []string, format textFormat) ([]string, error) {
+		calls++
+		translated := make([]string, len(chunk))
+		for i, text := range chunk {
+			translated[i] = text + " (pt)"
+		}
+		return translated, nil
+	}
+	cache := NewTranslationCache()
+	limits := batchLimits{maxItems: 10, maxChars: 100}
+
+	// 6 characters sent, the duplicate counts as a miss but is only sent once
+	if _, err := batchTranslate(context.Background(), cache, ProviderDeepL, []string{"Nebula", "Nebula"}, "en", "pt-BR", limits, translateChunk); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Answered by the cache
+	if _, err := batchTranslate(context.Background(), cache, ProviderDeepL, []string{"Nebula"}, "en", "pt-BR", limits, translateChunk); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// 6 + 6 characters would exceed the budget of 10
+	results, err := batchTranslate(context.Background(), cache, ProviderDeepL, []string{"Galaxy"}, "en", "pt-BR", limits, translateChunk)
+	if !errors.Is(err, ErrTranslationBudgetExceeded) {
+		t.Fatalf("Expected ErrTranslationBudgetExceeded, got %v", err)
+	}
+	if results[0] != "Galaxy" {
+		t.Errorf("Expected the original text, got %q", results[0])
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 provider call, got %d", calls)
+	}
+
+	// Other providers have no budget
+	if _, err := batchTranslate(context.Background(), NewTranslationCache(), ProviderGoogle, []string{"Galaxy"}, "en", "pt-BR", limits, translateChunk); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	day := usageNow()
+	report, err := GetTranslationUsageReport(context.Background(), day.AddDate(0, 0, -1), day)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []TranslationUsage{
+		{Date: "2025-01-15", Provider: ProviderDeepL, Lang: "pt-BR", Characters: 6, CacheHits: 1, CacheMisses: 3},
+		{Date: "2025-01-15", Provider: ProviderGoogle, Lang: "pt-BR", Characters: 6, CacheMisses: 1},
+	}
+	if len(report.Usage) != len(expected) {
+		t.Fatalf("Expected %d usage entries, got %+v", len(expected), report.Usage)
+	}
+	for i := range expected {
+		if report.Usage[i] != expected[i] {
+			t.Errorf("Usage %d: expected %+v, got %+v", i, expected[i], report.Usage[i])
+		}
+	}
+	if report.Totals.Characters != 12 || report.Totals.CacheHitRatio != 0.2 {
+		t.Errorf("Unexpected totals: %+v", report.Totals)
+	}
+	if len(report.Budgets) != 1 || report.Budgets[0].Used != 6 || report.Budgets[0].Remaining != 4 || report.Budgets[0].Exceeded {
+		t.Errorf("Unexpected budgets: %+v", report.Budgets)
+	}
+}
+
+// TestFallbackSkipsProviderOverBudget checks that a spent budget moves on to the next
+// provider without counting as a provider failure
+func TestFallbackSkipsProviderOverBudget(t *testing.T) {
+	fallback := NewFallbackTranslationService()
+	fallback.AddProvider(ProviderDeepL, budgetExceededService{}, NewCircuitBreaker(1, time.Minute))
+	fallback.AddProvider(ProviderMock, &mockTranslationService{}, NewCircuitBreaker(1, time.Minute))
+
+	translated, providers, err := fallback.translateBatch(context.Background(), []string{"Nebula"}, "en", "es")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if provider := providers[0]; provider != ProviderMock || translated[0] != "Nebula [es]" {
+		t.Errorf("Expected the mock translation, got %q from %q", translated[0], providers[0])
+	}
+	if status := fallback.Status()[0]; status.State != CircuitClosed || status.ConsecutiveFailures != 0 {
+		t.Errorf("Expected the circuit to stay closed, got %+v", status)
+	}
+}
+
+// TestReserveTranslationBudgetConcurrently checks that concurrent requests can't all pass
+// the budget check and overshoot it together, and that unused reservations are given back
+func TestReserveTranslationBudgetConcurrently(t *testing.T) {
+	useTestUsage(t, "pt-BR=100")
+
+	var wg sync.WaitGroup
+	var accepted atomic.Int32
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			settle, err := reserveTranslationBudget(context.Background(), ProviderDeepL, "pt-BR", 10)
+			if errors.Is(err, ErrTranslationBudgetExceeded) {
+				return
+			} else if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+				return
+			}
+			accepted.Add(1)
+			settle(10)
+		}()
+	}
+	wg.Wait()
+
+	if accepted.Load() > 10 {
+		t.Errorf("Expected at most 10 reservations of 10 characters, got %d", accepted.Load())
+	}
+	month, _ := usage.get(context.Background(), usageMonthKey(usageNow()))
+	if used := month[BudgetScopeLanguage+":pt-BR"]; used != int64(accepted.Load())*10 || used > 100 {
+		t.Errorf("Expected the accepted reservations only to be counted, got %d for %d", used, accepted.Load())
+	}
+
+	// A provider call that sent less than reserved gives the rest back
+	useTestUsage(t, "pt-BR=100")
+	settle, err := reserveTranslationBudget(context.Background(), ProviderDeepL, "pt-BR", 80)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	settle(30)
+	if _, err := reserveTranslationBudget(context.Background(), ProviderDeepL, "pt-BR", 70); err != nil {
+		t.Errorf("Expected the unused characters to be available again, got %v", err)
+	}
+}
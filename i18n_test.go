@@ -3,16 +3,21 @@ package main
 import (
 	"astrovista-api/i18n"
 	"astrovista-api/middleware"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 )
 
 // TestTranslationMiddleware verifies if the language detection middleware works correctly
 func TestTranslationMiddleware(t *testing.T) {
 	// Initialize i18n system
-	i18n.InitLocales()
+	if err := i18n.InitLocales(); err != nil {
+		t.Fatal(err)
+	}
 
 	// Create a test handler that simply returns the detected language
 	testHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -55,6 +60,36 @@ func TestTranslationMiddleware(t *testing.T) {
 			queryParam:     "fr",
 			expected:       "fr",
 		},
+		{
+			name:           "Regional fallback",
+			acceptLanguage: "pt-PT",
+			queryParam:     "",
+			expected:       "pt-BR",
+		},
+		{
+			name:           "Script fallback",
+			acceptLanguage: "zh-TW",
+			queryParam:     "",
+			expected:       "zh",
+		},
+		{
+			name:           "Quality values",
+			acceptLanguage: "de-CH;q=0.5, fr;q=0.9",
+			queryParam:     "",
+			expected:       "fr",
+		},
+		{
+			name:           "Unsupported header language",
+			acceptLanguage: "kl",
+			queryParam:     "",
+			expected:       "en",
+		},
+		{
+			name:           "Regional query parameter",
+			acceptLanguage: "",
+			queryParam:     "es-MX",
+			expected:       "es",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -93,6 +128,40 @@ func TestTranslationMiddleware(t *testing.T) {
 			if response["detectedLanguage"] != tc.expected {
 				t.Errorf("Expected language %q, got %q", tc.expected, response["detectedLanguage"])
 			}
+			if got := rr.Header().Get("Content-Language"); got != tc.expected {
+				t.Errorf("Expected Content-Language %q, got %q", tc.expected, got)
+			}
+			if got := rr.Header().Get("Vary"); !strings.Contains(got, "Accept-Language") {
+				t.Errorf("Expected Vary to include Accept-Language, got %q", got)
+			}
+		})
+	}
+}
+
+// TestTranslationMiddlewareInvalidLanguage verifies that an unsupported lang parameter is rejected
+func TestTranslationMiddlewareInvalidLanguage(t *testing.T) {
+	if err := i18n.InitLocales(); err != nil {
+		t.Fatal(err)
+	}
+
+	called := false
+	handler := middleware.LanguageDetector(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+
+	for _, lang := range []string{"xx", "kl", "not a language"} {
+		t.Run(lang, func(t *testing.T) {
+			called = false
+			req := httptest.NewRequest("GET", "/?lang="+url.QueryEscape(lang), nil)
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rr.Code)
+			}
+			if called {
+				t.Error("Expected the request not to reach the handler")
+			}
 		})
 	}
 }
@@ -100,7 +169,9 @@ func TestTranslationMiddleware(t *testing.T) {
 // TestTranslateAPOD tests the translation of APOD data
 func TestTranslateAPOD(t *testing.T) {
 	// Initialize i18n system
-	i18n.InitLocales()
+	if err := i18n.InitLocales(); err != nil {
+		t.Fatal(err)
+	}
 	i18n.InitTranslationService()
 
 	// Create a test APOD
@@ -124,7 +195,7 @@ func TestTranslateAPOD(t *testing.T) {
 			}
 
 			// Apply the translation
-			err := i18n.TranslateAPOD(apodCopy, lang)
+			err := i18n.TranslateAPOD(context.Background(), apodCopy, lang)
 			if err != nil {
 				t.Fatalf("Error translating APOD: %v", err)
 			}
@@ -1,14 +1,19 @@
 package main
 
 import (
+	"astrovista-api/apikeys"
+	"astrovista-api/auth"
 	"astrovista-api/cache"
 	"astrovista-api/database"
 	_ "astrovista-api/docs" // Importing docs for Swagger
 	"astrovista-api/handlers"
 	"astrovista-api/i18n"
 	"astrovista-api/middleware"
+	"context"
 	"log"
 	"net/http"
+	"os"
+	"strconv"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -23,9 +28,30 @@ func main() {
 	// Initialize database and cache connections
 	database.Connect()
 	cache.Connect()
+	responseCache := cache.NewFromEnv()
+	handlers.SetCache(responseCache, cache.LockerFromEnv())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	if err := apikeys.EnsureIndexes(ctx); err != nil {
+		log.Printf("Warning: Could not create API key indexes: %v", err)
+	}
+	if err := i18n.EnsureTranslationIndexes(ctx); err != nil {
+		log.Printf("Warning: Could not create translation indexes: %v", err)
+	}
+	if err := i18n.EnsureGlossaryIndexes(ctx); err != nil {
+		log.Printf("Warning: Could not create glossary indexes: %v", err)
+	}
+	if err := i18n.LoadGlossary(ctx); err != nil {
+		log.Printf("Warning: Could not load translation glossary: %v", err)
+	}
+	cancel()
 	// Initialize internationalization system
-	i18n.InitLocales()
+	if err := i18n.InitLocales(); err != nil {
+		log.Fatalf("Error loading locales: %v", err)
+	}
+	i18n.StartLocaleReload(time.Minute)
 	i18n.InitTranslationService()
+	i18n.StartPrecomputeWorker()
+	i18n.StartGlossaryRefresh(time.Minute)
 
 	router := mux.NewRouter()
 	// Swagger configuration
@@ -41,6 +67,65 @@ func main() {
 		http.Redirect(w, r, "/swagger/", http.StatusFound)
 	})
 
+	// Resolve the real client IP behind trusted proxies before any other middleware
+	clientIPResolver, err := middleware.NewClientIPResolver(middleware.TrustedProxiesFromEnv(), middleware.TrustedProxyHeaderFromEnv())
+	if err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES or TRUSTED_PROXY_HEADER: %v", err)
+	}
+	router.Use(clientIPResolver.Resolve)
+
+	// Authenticate operators with JWTs from the configured identity provider
+	authConfig := auth.ConfigFromEnv()
+	if authConfig.Enabled() {
+		verifier, err := auth.NewVerifier(authConfig)
+		if err != nil {
+			log.Fatalf("Error configuring JWT authentication: %v", err)
+		}
+		router.Use(middleware.JWTAuth(verifier))
+	}
+
+	// Authenticate API keys and enforce quotas (anonymous clients get the lower tier)
+	apiKeyConfig := middleware.APIKeyConfigFromEnv()
+	if !authConfig.Enabled() && !apiKeyConfig.Enabled() {
+		// Without any credential source nobody could issue the first keys, so refuse to start
+		// unless the operator explicitly accepts running with issued keys only
+		if allow, _ := strconv.ParseBool(os.Getenv("AUTH_ALLOW_UNCONFIGURED")); !allow {
+			log.Fatal("No JWKS, ADMIN_API_KEY or INTERNAL_API_TOKEN configured - set one of them, or AUTH_ALLOW_UNCONFIGURED=true to only accept already issued API keys")
+		}
+		log.Println("Warning: No JWKS, ADMIN_API_KEY or INTERNAL_API_TOKEN configured - only issued API keys can access admin and ingestion routes")
+	}
+	router.Use(middleware.APIKeyAuth(apiKeyConfig))
+
+	// Rate limiting is shared between replicas through Redis when available
+	var limiterBackend middleware.Limiter = middleware.NewMemoryLimiter()
+	if cache.Client != nil {
+		limiterBackend = middleware.NewRedisLimiter(cache.Client)
+	}
+	// Rate limiter: 1 request per minute
+	rateLimiter := middleware.NewRateLimiterWithBackend(limiterBackend, middleware.RateLimitPolicy{
+		Name:   "post-apod",
+		Limit:  1,
+		Window: 1 * time.Minute,
+	})
+
+	// Optional limit applied to every route (e.g. RATE_LIMIT_PUBLIC=60/1m)
+	if value := os.Getenv("RATE_LIMIT_PUBLIC"); value != "" {
+		policy, err := middleware.ParseRateLimitPolicy("public", value)
+		if err != nil {
+			log.Fatalf("Invalid RATE_LIMIT_PUBLIC: %v", err)
+		}
+		router.Use(rateLimiter.WithPolicy(policy).Limit)
+	}
+
+	// Compress responses after authentication and rate limiting, so cached responses
+	// are still counted and limited, and before formatting, so it sees the final body
+	compressionConfig := middleware.DefaultCompressionConfig()
+	if ttl, err := time.ParseDuration(os.Getenv("COMPRESSION_CACHE_TTL")); err == nil {
+		compressionConfig.CacheTTL = ttl
+	}
+	compressionConfig.Cache = responseCache
+	router.Use(middleware.Compression(compressionConfig))
+
 	// Public GET endpoints (no rate limit) // Add middleware for JSON formatting and language detection
 	router.Use(middleware.JSONFormatterMiddleware)
 	router.Use(middleware.LanguageDetector)
@@ -50,13 +135,29 @@ func main() {
 	router.HandleFunc("/apods/search", handlers.SearchApods).Methods("GET")
 	router.HandleFunc("/apods/date-range", handlers.GetApodsDateRange).Methods("GET")
 	router.HandleFunc("/languages", handlers.GetSupportedLanguages).Methods("GET")
-	// Rate limiter: 1 request per minute
-	rateLimiter := middleware.NewRateLimiter(1, 1*time.Minute)
+	router.HandleFunc("/translation/status", handlers.GetTranslationStatus).Methods("GET")
 
 	// POST endpoint with applied rate limit
 	postRouter := router.PathPrefix("/apod").Subrouter()
+	postRouter.Use(middleware.RequireRole(apikeys.ScopeIngest))
 	postRouter.Use(rateLimiter.Limit)
 	postRouter.HandleFunc("", handlers.PostApod).Methods("POST")
+
+	// Admin endpoints for API key management, glossary and translation review
+	adminRouter := router.PathPrefix("/admin").Subrouter()
+	adminRouter.Use(middleware.RequireRole(apikeys.ScopeAdmin))
+	adminRouter.HandleFunc("/keys", handlers.CreateAPIKey).Methods("POST")
+	adminRouter.HandleFunc("/keys", handlers.ListAPIKeys).Methods("GET")
+	adminRouter.HandleFunc("/keys/{id}/rotate", handlers.RotateAPIKey).Methods("POST")
+	adminRouter.HandleFunc("/keys/{id}", handlers.RevokeAPIKey).Methods("DELETE")
+	adminRouter.HandleFunc("/glossary", handlers.ListGlossary).Methods("GET")
+	adminRouter.HandleFunc("/glossary", handlers.CreateGlossaryEntry).Methods("POST")
+	adminRouter.HandleFunc("/glossary/{id}", handlers.DeleteGlossaryEntry).Methods("DELETE")
+	adminRouter.HandleFunc("/translations/usage", handlers.GetTranslationUsage).Methods("GET")
+	adminRouter.HandleFunc("/translations/status", handlers.GetAdminTranslationStatus).Methods("GET")
+	adminRouter.HandleFunc("/translations/{date}/{lang}", handlers.GetAPODTranslationReview).Methods("GET")
+	adminRouter.HandleFunc("/translations/{date}/{lang}/{field}", handlers.UpdateAPODTranslation).Methods("PUT")
+	adminRouter.HandleFunc("/translations/{date}/{lang}/{field}/approve", handlers.ApproveAPODTranslation).Methods("POST")
 	// Determine server port (default 8080, or use PORT environment variable)
 	port := "8080"
 
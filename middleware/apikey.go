@@ -0,0 +1,210 @@
+package middleware
+
+import (
+	"astrovista-api/apikeys"
+	"astrovista-api/i18n"
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// APIKeyHeader is the request header carrying an issued API key
+const APIKeyHeader = "X-API-Key"
+
+// findAPIKey resolves issued API keys (replaced in tests)
+var findAPIKey = apikeys.FindByKey
+
+// Context key to store the authenticated principal
+type principalKey struct{}
+
+// Principal is the caller of a request: a token user, an API key holder or an anonymous client
+type Principal struct {
+	Subject   string   // Stable identifier used for usage tracking
+	Name      string   // Human readable name
+	Roles     []string // Granted roles (JWT roles or API key scopes)
+	Anonymous bool
+}
+
+// HasRole checks whether the principal was granted a role (admin implies every role)
+func (p *Principal) HasRole(role string) bool {
+	if p == nil {
+		return false
+	}
+	for _, r := range p.Roles {
+		if r == role || r == apikeys.ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPrincipalFromContext extracts the authenticated principal from the request context
+func GetPrincipalFromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(principalKey{}).(*Principal)
+	return principal
+}
+
+// APIKeyConfig configures the API key middleware
+type APIKeyConfig struct {
+	// Quotas of the anonymous tier, per client IP (0 means unlimited)
+	AnonymousDailyQuota   int64
+	AnonymousMonthlyQuota int64
+	// BootstrapAdminKey is an admin key read from the environment, used to issue the first keys
+	BootstrapAdminKey string
+	// InternalToken is the legacy X-API-Token value granting the ingest role (empty disables it)
+	InternalToken string
+	// ExemptPrefixes are paths that don't count towards quotas (e.g. documentation assets)
+	ExemptPrefixes []string
+}
+
+// APIKeyConfigFromEnv reads the API key configuration from environment variables
+func APIKeyConfigFromEnv() APIKeyConfig {
+	return APIKeyConfig{
+		AnonymousDailyQuota:   int64FromEnv("ANONYMOUS_DAILY_QUOTA", 1000),
+		AnonymousMonthlyQuota: int64FromEnv("ANONYMOUS_MONTHLY_QUOTA", 20000),
+		BootstrapAdminKey:     os.Getenv("ADMIN_API_KEY"),
+		InternalToken:         os.Getenv("INTERNAL_API_TOKEN"),
+		ExemptPrefixes:        []string{"/swagger/"},
+	}
+}
+
+// Enabled reports whether any credential is configured through the environment
+func (c APIKeyConfig) Enabled() bool {
+	return c.BootstrapAdminKey != "" || c.InternalToken != ""
+}
+
+// APIKeyAuth returns a middleware that authenticates X-API-Key, enforces quotas and
+// stores the resulting Principal in the request context. Requests without a key are
+// served at the anonymous tier, unless an earlier middleware (JWTAuth) already
+// authenticated them.
+func APIKeyAuth(config APIKeyConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal := &Principal{
+				Subject:   "anon:" + clientKey(r),
+				Name:      "anonymous",
+				Roles:     []string{apikeys.ScopeRead},
+				Anonymous: true,
+			}
+			dailyQuota := config.AnonymousDailyQuota
+			monthlyQuota := config.AnonymousMonthlyQuota
+
+			if authenticated := GetPrincipalFromContext(r.Context()); authenticated != nil {
+				// Token users are operators, so no quota applies
+				principal = authenticated
+				dailyQuota, monthlyQuota = 0, 0
+			} else if token := r.Header.Get("X-API-Token"); token != "" {
+				// Legacy shared token for the scheduled ingestion job
+				if config.InternalToken == "" || !apikeys.Equal(token, config.InternalToken) {
+					writeError(w, r, http.StatusUnauthorized, i18n.CodeUnauthorized, "valid X-API-Token required")
+					return
+				}
+				principal = &Principal{
+					Subject: "internal-token",
+					Name:    "internal token",
+					Roles:   []string{apikeys.ScopeIngest},
+				}
+				dailyQuota, monthlyQuota = 0, 0
+			} else if key := r.Header.Get(APIKeyHeader); key != "" {
+				if config.BootstrapAdminKey != "" && apikeys.Equal(key, config.BootstrapAdminKey) {
+					principal = &Principal{
+						Subject: "bootstrap-admin",
+						Name:    "bootstrap admin",
+						Roles:   []string{apikeys.ScopeAdmin},
+					}
+					dailyQuota, monthlyQuota = 0, 0
+				} else {
+					apiKey, err := findAPIKey(r.Context(), key)
+					if err == apikeys.ErrNotFound {
+						writeError(w, r, http.StatusUnauthorized, i18n.CodeInvalidAPIKey, "")
+						return
+					} else if err != nil {
+						log.Printf("Error looking up API key: %v", err)
+						writeError(w, r, http.StatusInternalServerError, i18n.CodeAuthError, "")
+						return
+					}
+					principal = &Principal{
+						Subject: apiKey.Subject(),
+						Name:    apiKey.Name,
+						Roles:   apiKey.Scopes,
+					}
+					dailyQuota, monthlyQuota = apiKey.DailyQuota, apiKey.MonthlyQuota
+				}
+			}
+
+			if !isExempt(r.URL.Path, config.ExemptPrefixes) {
+				usage, err := apikeys.IncrementUsage(r.Context(), principal.Subject)
+				if err != nil {
+					// Fail open: usage tracking problems should not block requests
+					log.Printf("Error tracking API usage: %v", err)
+				} else {
+					setQuotaHeaders(w, usage, dailyQuota, monthlyQuota)
+					if apikeys.QuotaExceeded(usage, dailyQuota, monthlyQuota) {
+						writeError(w, r, http.StatusTooManyRequests, i18n.CodeQuotaExceeded, "")
+						return
+					}
+				}
+			}
+
+			ctx := context.WithValue(r.Context(), principalKey{}, principal)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequireRole returns a middleware that only lets principals with the given role through
+func RequireRole(role string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal := GetPrincipalFromContext(r.Context())
+			if principal == nil || principal.Anonymous {
+				writeError(w, r, http.StatusUnauthorized, i18n.CodeUnauthorized, "")
+				return
+			}
+			if !principal.HasRole(role) {
+				writeError(w, r, http.StatusForbidden, i18n.CodeForbidden, "missing role: "+role)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// setQuotaHeaders reports the remaining quota to the client
+func setQuotaHeaders(w http.ResponseWriter, usage apikeys.Usage, dailyQuota, monthlyQuota int64) {
+	if dailyQuota > 0 {
+		w.Header().Set("X-Quota-Daily-Limit", strconv.FormatInt(dailyQuota, 10))
+		w.Header().Set("X-Quota-Daily-Remaining", strconv.FormatInt(max(dailyQuota-usage.Day, 0), 10))
+	}
+	if monthlyQuota > 0 {
+		w.Header().Set("X-Quota-Monthly-Limit", strconv.FormatInt(monthlyQuota, 10))
+		w.Header().Set("X-Quota-Monthly-Remaining", strconv.FormatInt(max(monthlyQuota-usage.Month, 0), 10))
+	}
+}
+
+// isExempt checks whether a path is excluded from quota accounting
+func isExempt(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// int64FromEnv reads a number from the environment, falling back to a default
+func int64FromEnv(name string, defaultValue int64) int64 {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		log.Printf("Invalid value for %s ignored: %s (using %d as default)", name, value, defaultValue)
+		return defaultValue
+	}
+	return n
+}
@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"astrovista-api/apikeys"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TestAPIKeyAuth checks each credential path and their precedence: JWT, then X-API-Token,
+// then X-API-Key, then anonymous
+func TestAPIKeyAuth(t *testing.T) {
+	issued := &apikeys.APIKey{ID: primitive.NewObjectID(), Name: "Mobile app", Scopes: []string{apikeys.ScopeRead}}
+	previous := findAPIKey
+	defer func() { findAPIKey = previous }()
+	findAPIKey = func(ctx context.Context, key string) (*apikeys.APIKey, error) {
+		switch key {
+		case "av_issued":
+			return issued, nil
+		case "av_broken":
+			return nil, errors.New("database unavailable")
+		default:
+			// Unknown and revoked keys are not found
+			return nil, apikeys.ErrNotFound
+		}
+	}
+
+	config := APIKeyConfig{BootstrapAdminKey: "av_bootstrap", InternalToken: "internal-secret"}
+	var principal *Principal
+	handler := APIKeyAuth(config)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal = GetPrincipalFromContext(r.Context())
+	}))
+	jwtPrincipal := &Principal{Subject: "jwt:editor", Name: "editor", Roles: []string{apikeys.ScopeAdmin}}
+
+	testCases := []struct {
+		name            string
+		jwt             bool
+		headers         map[string]string
+		expectedStatus  int
+		expectedSubject string
+	}{
+		{"Anonymous", false, nil, http.StatusOK, "anon:192.0.2.1"},
+		{"Issued key", false, map[string]string{APIKeyHeader: "av_issued"}, http.StatusOK, issued.Subject()},
+		{"Bootstrap admin key", false, map[string]string{APIKeyHeader: "av_bootstrap"}, http.StatusOK, "bootstrap-admin"},
+		{"Revoked key", false, map[string]string{APIKeyHeader: "av_revoked"}, http.StatusUnauthorized, ""},
+		{"Key lookup error", false, map[string]string{APIKeyHeader: "av_broken"}, http.StatusInternalServerError, ""},
+		{"Internal token", false, map[string]string{"X-API-Token": "internal-secret"}, http.StatusOK, "internal-token"},
+		{"Invalid internal token", false, map[string]string{"X-API-Token": "wrong"}, http.StatusUnauthorized, ""},
+		{"Internal token before API key", false, map[string]string{"X-API-Token": "internal-secret", APIKeyHeader: "av_revoked"}, http.StatusOK, "internal-token"},
+		{"JWT before every header", true, map[string]string{"X-API-Token": "wrong", APIKeyHeader: "av_revoked"}, http.StatusOK, "jwt:editor"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			principal = nil
+			req := httptest.NewRequest("GET", "/apods", nil)
+			req.RemoteAddr = "192.0.2.1:1234"
+			for name, value := range tc.headers {
+				req.Header.Set(name, value)
+			}
+			if tc.jwt {
+				req = req.WithContext(context.WithValue(req.Context(), principalKey{}, jwtPrincipal))
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tc.expectedStatus, rr.Code)
+			}
+			if tc.expectedStatus != http.StatusOK {
+				if principal != nil {
+					t.Error("Expected the request to be rejected before the handler")
+				}
+				return
+			}
+			if principal == nil || principal.Subject != tc.expectedSubject {
+				t.Errorf("Expected subject %q, got %+v", tc.expectedSubject, principal)
+			}
+		})
+	}
+}
+
+// TestRequireRole checks that only principals with the role get through
+func TestRequireRole(t *testing.T) {
+	handler := RequireRole(apikeys.ScopeIngest)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	testCases := []struct {
+		name           string
+		principal      *Principal
+		expectedStatus int
+	}{
+		{"No principal", nil, http.StatusUnauthorized},
+		{"Anonymous", &Principal{Roles: []string{apikeys.ScopeRead}, Anonymous: true}, http.StatusUnauthorized},
+		{"Missing role", &Principal{Roles: []string{apikeys.ScopeRead}}, http.StatusForbidden},
+		{"Granted role", &Principal{Roles: []string{apikeys.ScopeIngest}}, http.StatusOK},
+		{"Admin", &Principal{Roles: []string{apikeys.ScopeAdmin}}, http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/apod", nil)
+			if tc.principal != nil {
+				req = req.WithContext(context.WithValue(req.Context(), principalKey{}, tc.principal))
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			if rr.Code != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tc.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
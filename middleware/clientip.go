@@ -0,0 +1,262 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Context key to store the resolved client
+type clientIPKey struct{}
+
+// resolvedClient is the client of a request, as stored in the request context
+type resolvedClient struct {
+	// Real IP, nil when a trusted proxy only gave an obfuscated identifier
+	ip net.IP
+	// Identifier of the client for rate limiting: the IP, with IPv6 addresses grouped by
+	// /64, or the obfuscated identifier
+	key string
+}
+
+// forwardedHop is one hop of a forwarding header, with the raw value of hops that are
+// not an IP address (e.g. the "unknown" or "_hidden" identifiers of RFC 7239)
+type forwardedHop struct {
+	ip  net.IP
+	raw string
+}
+
+// Forwarding headers a resolver can read the client from
+const (
+	HeaderForwardedFor = "X-Forwarded-For"
+	HeaderForwarded    = "Forwarded"
+	HeaderRealIP       = "X-Real-IP"
+)
+
+// ClientIPResolver determines the real client IP of a request. The forwarding header is
+// only trusted when the request comes directly from one of the configured proxy networks.
+type ClientIPResolver struct {
+	trustedProxies []*net.IPNet
+	// The only forwarding header read. Proxies usually pass the other ones through as
+	// the client sent them, so reading them would let clients choose their address.
+	header string
+}
+
+// NewClientIPResolver creates a resolver that trusts the given CIDRs (or single IPs) to
+// set the given forwarding header (X-Forwarded-For when empty)
+func NewClientIPResolver(trustedProxies []string, header string) (*ClientIPResolver, error) {
+	resolver := &ClientIPResolver{}
+	for _, supported := range []string{HeaderForwardedFor, HeaderForwarded, HeaderRealIP} {
+		if strings.EqualFold(strings.TrimSpace(header), supported) {
+			resolver.header = supported
+		}
+	}
+	switch {
+	case resolver.header != "":
+	case strings.TrimSpace(header) == "":
+		resolver.header = HeaderForwardedFor
+	default:
+		return nil, fmt.Errorf("unsupported forwarding header %q (expected %s, %s or %s)", header, HeaderForwardedFor, HeaderForwarded, HeaderRealIP)
+	}
+	for _, value := range trustedProxies {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		// Single addresses are accepted as a /32 or /128 network
+		if !strings.Contains(value, "/") {
+			ip := net.ParseIP(value)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy address %q", value)
+			}
+			bits := 128
+			if ip.To4() != nil {
+				bits = 32
+			}
+			value = fmt.Sprintf("%s/%d", value, bits)
+		}
+
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy network %q: %v", value, err)
+		}
+		resolver.trustedProxies = append(resolver.trustedProxies, network)
+	}
+	return resolver, nil
+}
+
+// TrustedProxiesFromEnv returns the trusted proxy networks configured in TRUSTED_PROXIES
+// as a comma-separated list (e.g. "10.0.0.0/8,192.168.1.10")
+func TrustedProxiesFromEnv() []string {
+	value := os.Getenv("TRUSTED_PROXIES")
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// TrustedProxyHeaderFromEnv returns the forwarding header set by the trusted proxies,
+// configured in TRUSTED_PROXY_HEADER (X-Forwarded-For by default)
+func TrustedProxyHeaderFromEnv() string {
+	return os.Getenv("TRUSTED_PROXY_HEADER")
+}
+
+// Resolve is a middleware that stores the resolved client in the request context
+func (cr *ClientIPResolver) Resolve(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), clientIPKey{}, cr.resolve(r))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// ClientIP returns the IP of the client that made the request, or nil when a trusted
+// proxy hides it behind an obfuscated identifier
+func (cr *ClientIPResolver) ClientIP(r *http.Request) net.IP {
+	return cr.clientHop(r).ip
+}
+
+// resolve returns the IP and the identifier of the client that made the request
+func (cr *ClientIPResolver) resolve(r *http.Request) resolvedClient {
+	hop := cr.clientHop(r)
+	if hop.ip == nil {
+		return resolvedClient{key: hop.raw}
+	}
+	return resolvedClient{ip: hop.ip, key: normalizeClientIP(hop.ip)}
+}
+
+// clientHop returns the hop of the client that made the request
+func (cr *ClientIPResolver) clientHop(r *http.Request) forwardedHop {
+	remote := forwardedHop{ip: parseAddress(r.RemoteAddr)}
+	if remote.ip == nil || !cr.isTrusted(remote.ip) {
+		// Headers from untrusted peers could be forged, so only the peer address counts
+		return remote
+	}
+
+	// Only the configured header: the others may come unchanged from the client
+	var chain []forwardedHop
+	if cr.header == HeaderForwarded {
+		chain = parseForwardedHeader(r.Header.Values(cr.header))
+	} else {
+		// X-Real-IP is a chain of a single hop
+		chain = parseForwardedFor(r.Header.Values(cr.header))
+	}
+	if len(chain) == 0 {
+		return remote
+	}
+	return cr.clientFromChain(chain)
+}
+
+// clientFromChain walks the proxy chain from the closest hop and returns the first hop
+// that is not a trusted proxy. A hop that is not an IP address was written by a trusted
+// proxy that doesn't disclose the client, so it is the client, known by its identifier;
+// the hops before it can't be trusted.
+func (cr *ClientIPResolver) clientFromChain(chain []forwardedHop) forwardedHop {
+	for i := len(chain) - 1; i >= 0; i-- {
+		if chain[i].ip == nil {
+			return chain[i]
+		}
+		if !cr.isTrusted(chain[i].ip) {
+			return chain[i]
+		}
+	}
+	// Every hop is a trusted proxy, so the original sender is the leftmost one
+	return chain[0]
+}
+
+// isTrusted checks whether the IP belongs to a trusted proxy network
+func (cr *ClientIPResolver) isTrusted(ip net.IP) bool {
+	for _, network := range cr.trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseForwardedFor parses X-Forwarded-For headers into a list of hops
+func parseForwardedFor(values []string) []forwardedHop {
+	var chain []forwardedHop
+	for _, value := range values {
+		for _, hop := range strings.Split(value, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				chain = append(chain, newForwardedHop(hop))
+			}
+		}
+	}
+	return chain
+}
+
+// parseForwardedHeader extracts the "for" parameters of RFC 7239 Forwarded headers
+func parseForwardedHeader(values []string) []forwardedHop {
+	var chain []forwardedHop
+	for _, value := range values {
+		for _, element := range strings.Split(value, ",") {
+			for _, pair := range strings.Split(element, ";") {
+				key, val, found := strings.Cut(strings.TrimSpace(pair), "=")
+				if !found || !strings.EqualFold(strings.TrimSpace(key), "for") {
+					continue
+				}
+				chain = append(chain, newForwardedHop(strings.Trim(strings.TrimSpace(val), `"`)))
+			}
+		}
+	}
+	return chain
+}
+
+// newForwardedHop parses a hop of a forwarding header. Values that are not an IP address
+// are kept as an identifier, "unknown" when empty.
+func newForwardedHop(value string) forwardedHop {
+	if ip := parseAddress(value); ip != nil {
+		return forwardedHop{ip: ip}
+	}
+	if value = strings.TrimSpace(value); value == "" {
+		value = "unknown"
+	}
+	return forwardedHop{raw: value}
+}
+
+// parseAddress parses an IP address with an optional port and IPv6 brackets
+func parseAddress(value string) net.IP {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	if host, _, err := net.SplitHostPort(value); err == nil {
+		value = host
+	}
+	return net.ParseIP(strings.Trim(value, "[]"))
+}
+
+// normalizeClientIP formats the client IP for use as an identifier. IPv6 clients usually
+// control a whole /64, so addresses are grouped by that prefix.
+func normalizeClientIP(ip net.IP) string {
+	if ip == nil {
+		return ""
+	}
+	if ipv4 := ip.To4(); ipv4 != nil {
+		return ipv4.String()
+	}
+	prefix := ip.Mask(net.CIDRMask(64, 128))
+	return prefix.String() + "/64"
+}
+
+// GetClientIPFromContext extracts the resolved client IP from the request context. It is
+// empty when a trusted proxy hides the client behind an obfuscated identifier.
+func GetClientIPFromContext(ctx context.Context) string {
+	client, _ := ctx.Value(clientIPKey{}).(resolvedClient)
+	if client.ip == nil {
+		return ""
+	}
+	return client.ip.String()
+}
+
+// GetClientKeyFromContext extracts the identifier of the resolved client from the request
+// context: its IP, with IPv6 addresses grouped by /64, or the obfuscated identifier a
+// trusted proxy gave instead
+func GetClientKeyFromContext(ctx context.Context) string {
+	client, _ := ctx.Value(clientIPKey{}).(resolvedClient)
+	return client.key
+}
@@ -0,0 +1,151 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestClientIPResolver verifies that forwarding headers are only honoured from trusted proxies
+func TestClientIPResolver(t *testing.T) {
+	testCases := []struct {
+		name       string
+		header     string // Forwarding header set by the proxies, X-Forwarded-For when empty
+		remoteAddr string
+		headers    map[string]string
+		expected   string // Client key
+		expectedIP string
+	}{
+		{
+			name:       "Direct client",
+			remoteAddr: "203.0.113.5:4000",
+			expected:   "203.0.113.5",
+			expectedIP: "203.0.113.5",
+		},
+		{
+			name:       "Untrusted peer cannot spoof headers",
+			remoteAddr: "203.0.113.5:4000",
+			headers:    map[string]string{"X-Forwarded-For": "198.51.100.1"},
+			expected:   "203.0.113.5",
+			expectedIP: "203.0.113.5",
+		},
+		{
+			name:       "X-Forwarded-For from trusted proxy",
+			remoteAddr: "10.1.2.3:4000",
+			headers:    map[string]string{"X-Forwarded-For": "198.51.100.1, 192.168.1.10"},
+			expected:   "198.51.100.1",
+			expectedIP: "198.51.100.1",
+		},
+		{
+			name:       "Spoofed leftmost hop is ignored",
+			remoteAddr: "10.1.2.3:4000",
+			headers:    map[string]string{"X-Forwarded-For": "1.1.1.1, 198.51.100.1"},
+			expected:   "198.51.100.1",
+			expectedIP: "198.51.100.1",
+		},
+		{
+			name:       "Forwarded header",
+			header:     "Forwarded",
+			remoteAddr: "10.1.2.3:4000",
+			headers:    map[string]string{"Forwarded": `for="[2001:db8:cafe:1:2::17]:4711";proto=https, for=10.0.0.2`},
+			expected:   "2001:db8:cafe:1::/64",
+			expectedIP: "2001:db8:cafe:1:2::17",
+		},
+		{
+			name:       "X-Real-IP from trusted proxy",
+			header:     "x-real-ip",
+			remoteAddr: "192.168.1.10:4000",
+			headers:    map[string]string{"X-Real-IP": "198.51.100.7"},
+			expected:   "198.51.100.7",
+			expectedIP: "198.51.100.7",
+		},
+		{
+			name:       "IPv6 client is grouped by /64",
+			remoteAddr: "[2001:db8:aa:bb:1:2:3:4]:4000",
+			expected:   "2001:db8:aa:bb::/64",
+			expectedIP: "2001:db8:aa:bb:1:2:3:4",
+		},
+		{
+			name:       "Obfuscated client behind a trusted proxy",
+			header:     "Forwarded",
+			remoteAddr: "10.1.2.3:4000",
+			headers:    map[string]string{"Forwarded": `for=198.51.100.1, for=_hidden, for=10.0.0.2`},
+			expected:   "_hidden",
+			expectedIP: "",
+		},
+		{
+			name:       "Obfuscated client from the closest proxy",
+			header:     "Forwarded",
+			remoteAddr: "10.1.2.3:4000",
+			headers:    map[string]string{"Forwarded": `for=unknown`},
+			expected:   "unknown",
+			expectedIP: "",
+		},
+		{
+			name:       "Unparseable X-Forwarded-For hop",
+			remoteAddr: "10.1.2.3:4000",
+			headers:    map[string]string{"X-Forwarded-For": "198.51.100.1, garbage, 10.0.0.2"},
+			expected:   "garbage",
+			expectedIP: "",
+		},
+		{
+			name:       "Spoofed Forwarded header next to the proxy's X-Forwarded-For",
+			remoteAddr: "10.1.2.3:4000",
+			headers:    map[string]string{"Forwarded": "for=1.2.3.4", "X-Forwarded-For": "198.51.100.1"},
+			expected:   "198.51.100.1",
+			expectedIP: "198.51.100.1",
+		},
+		{
+			name:       "Spoofed X-Real-IP without X-Forwarded-For",
+			remoteAddr: "10.1.2.3:4000",
+			headers:    map[string]string{"X-Real-IP": "1.2.3.4"},
+			expected:   "10.1.2.3",
+			expectedIP: "10.1.2.3",
+		},
+		{
+			name:       "Spoofed X-Forwarded-For next to the proxy's Forwarded header",
+			header:     "Forwarded",
+			remoteAddr: "10.1.2.3:4000",
+			headers:    map[string]string{"Forwarded": "for=198.51.100.1", "X-Forwarded-For": "1.2.3.4"},
+			expected:   "198.51.100.1",
+			expectedIP: "198.51.100.1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resolver, err := NewClientIPResolver([]string{"10.0.0.0/8", "192.168.1.10"}, tc.header)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req := httptest.NewRequest("GET", "/apod", nil)
+			req.RemoteAddr = tc.remoteAddr
+			for key, value := range tc.headers {
+				req.Header.Set(key, value)
+			}
+
+			var ctxKey, ctxIP string
+			resolver.Resolve(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ctxKey, ctxIP = GetClientKeyFromContext(r.Context()), GetClientIPFromContext(r.Context())
+			})).ServeHTTP(httptest.NewRecorder(), req)
+
+			if ctxKey != tc.expected {
+				t.Errorf("Expected client key %q, got %q", tc.expected, ctxKey)
+			}
+			if ctxIP != tc.expectedIP {
+				t.Errorf("Expected client IP %q, got %q", tc.expectedIP, ctxIP)
+			}
+		})
+	}
+}
+
+// TestClientIPResolverHeader checks that only the supported forwarding headers are accepted
+func TestClientIPResolverHeader(t *testing.T) {
+	if _, err := NewClientIPResolver(nil, "X-Client-IP"); err == nil {
+		t.Error("Expected an error for an unsupported header")
+	}
+	resolver, err := NewClientIPResolver(nil, "")
+	if err != nil || resolver.header != HeaderForwardedFor {
+		t.Errorf("Expected X-Forwarded-For by default, got %+v (%v)", resolver, err)
+	}
+}
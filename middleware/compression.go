@@ -0,0 +1,376 @@
+package middleware
+
+import (
+	"astrovista-api/cache"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Supported content encodings, in order of server preference
+const (
+	encodingBrotli = "br"
+	encodingZstd   = "zstd"
+	encodingGzip   = "gzip"
+)
+
+var serverEncodings = []string{encodingBrotli, encodingZstd, encodingGzip}
+
+// CompressionConfig configures the compression middleware
+type CompressionConfig struct {
+	// MinSize is the minimum body size (in bytes) for a response to be compressed
+	MinSize int
+	// CacheTTL enables storing pre-compressed GET responses in Cache when greater than zero
+	CacheTTL time.Duration
+	// Cache stores the pre-compressed responses
+	Cache cache.Cache
+}
+
+// DefaultCompressionConfig returns the default compression settings
+func DefaultCompressionConfig() CompressionConfig {
+	return CompressionConfig{
+		MinSize:  1024, // Smaller bodies are not worth the CPU time
+		CacheTTL: 0,    // Pre-compressed cache disabled by default
+	}
+}
+
+// CompressedCacheTag tags the pre-compressed responses in the cache. Their bodies are
+// translated, so every change to APODs, translations or the glossary invalidates it.
+const CompressedCacheTag = "compressed"
+
+// partialTranslationHeader marks responses with untranslated fields (see handlers.TranslationPartialHeader)
+const partialTranslationHeader = "X-Translation-Partial"
+
+// credentialHeaders identify a caller; responses to these requests are never cached
+var credentialHeaders = []string{"Authorization", APIKeyHeader, "X-API-Token"}
+
+// compressedCacheEntry is a pre-compressed response stored in the cache
+type compressedCacheEntry struct {
+	Header http.Header `json:"header"` // Headers set by the handler, replayed on hits
+	Body   []byte      `json:"body"`
+}
+
+// Compression returns a middleware that compresses responses with brotli, zstd or gzip
+// according to the client's Accept-Encoding header
+func Compression(config CompressionConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// The response depends on Accept-Encoding, even when we end up not compressing it
+			addVary(w.Header(), "Accept-Encoding")
+
+			encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+			if encoding == "" || r.Method == http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// Serve a pre-compressed body from the cache if available
+			cacheKey := ""
+			if config.CacheTTL > 0 && config.Cache != nil && cacheableRequest(r) {
+				cacheKey = compressedCacheKey(r, encoding)
+				var entry compressedCacheEntry
+				found, err := config.Cache.Get(r.Context(), cacheKey, &entry)
+				if err != nil {
+					log.Printf("Error accessing compressed response cache: %v", err)
+				}
+				if found {
+					for name, values := range entry.Header {
+						w.Header()[name] = values
+					}
+					w.Header().Set("Content-Encoding", encoding)
+					w.Header().Set("Content-Length", strconv.Itoa(len(entry.Body)))
+					w.Header().Set("X-Cache", "HIT")
+					w.WriteHeader(http.StatusOK)
+					w.Write(entry.Body)
+					return
+				}
+			}
+
+			cw := &compressResponseWriter{
+				ResponseWriter: w,
+				encoding:       encoding,
+				minSize:        config.MinSize,
+				status:         http.StatusOK,
+			}
+			// Headers already set by earlier middlewares (quotas, rate limits) belong to this request only
+			var initialHeader http.Header
+			if cacheKey != "" {
+				cw.cacheBuffer = &bytes.Buffer{}
+				initialHeader = w.Header().Clone()
+			}
+
+			next.ServeHTTP(cw, r)
+
+			if err := cw.Close(); err != nil {
+				log.Printf("Error finishing compressed response: %v", err)
+				return
+			}
+
+			// Store the compressed body for future requests
+			// Partial translations are degraded responses and must not outlive the request
+			if cacheKey != "" && cw.compressed && cw.status == http.StatusOK && w.Header().Get(partialTranslationHeader) == "" {
+				entry := compressedCacheEntry{
+					Header: handlerHeaders(initialHeader, w.Header()),
+					Body:   cw.cacheBuffer.Bytes(),
+				}
+				ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+				defer cancel()
+				var err error
+				if tagger, ok := config.Cache.(cache.Tagger); ok {
+					err = tagger.SetWithTags(ctx, cacheKey, entry, config.CacheTTL, CompressedCacheTag)
+				} else {
+					err = config.Cache.Set(ctx, cacheKey, entry, config.CacheTTL)
+				}
+				if err != nil {
+					log.Printf("Error storing compressed response in cache: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// compressResponseWriter buffers the beginning of the response until it knows whether
+// the body is large enough to be compressed, then streams through the encoder
+type compressResponseWriter struct {
+	http.ResponseWriter
+	encoding    string
+	minSize     int
+	status      int
+	buffer      []byte
+	encoder     io.WriteCloser
+	cacheBuffer *bytes.Buffer
+	committed   bool // Headers have been sent to the client
+	compressed  bool // Body is being written through the encoder
+}
+
+// WriteHeader records the status code; it is sent once the encoding is decided
+func (w *compressResponseWriter) WriteHeader(code int) {
+	if w.committed {
+		return
+	}
+	w.status = code
+}
+
+// Write buffers the body until MinSize is reached, then compresses it
+func (w *compressResponseWriter) Write(b []byte) (int, error) {
+	if w.committed {
+		if w.compressed {
+			return w.encoder.Write(b)
+		}
+		return w.ResponseWriter.Write(b)
+	}
+
+	w.buffer = append(w.buffer, b...)
+	if len(w.buffer) >= w.minSize {
+		if err := w.commit(); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+// Flush sends buffered data to the client, which supports streamed responses
+func (w *compressResponseWriter) Flush() {
+	if !w.committed {
+		if err := w.commit(); err != nil {
+			log.Printf("Error flushing compressed response: %v", err)
+			return
+		}
+	}
+	if flusher, ok := w.encoder.(interface{ Flush() error }); ok && w.compressed {
+		flusher.Flush()
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Close writes any remaining buffered data and finishes the compressed stream
+func (w *compressResponseWriter) Close() error {
+	if !w.committed {
+		// The whole body fits under MinSize, so it is sent uncompressed
+		w.committed = true
+		w.ResponseWriter.WriteHeader(w.status)
+		if len(w.buffer) > 0 {
+			_, err := w.ResponseWriter.Write(w.buffer)
+			return err
+		}
+		return nil
+	}
+	if w.compressed {
+		return w.encoder.Close()
+	}
+	return nil
+}
+
+// commit decides whether to compress, sends the headers and flushes the buffer
+func (w *compressResponseWriter) commit() error {
+	w.committed = true
+	header := w.Header()
+
+	if len(w.buffer) >= w.minSize && shouldCompress(header, w.status) {
+		header.Set("Content-Encoding", w.encoding)
+		header.Del("Content-Length") // The length of the compressed body is not known upfront
+
+		var target io.Writer = w.ResponseWriter
+		if w.cacheBuffer != nil {
+			target = io.MultiWriter(w.ResponseWriter, w.cacheBuffer)
+		}
+		encoder, err := newEncoder(w.encoding, target)
+		if err != nil {
+			return err
+		}
+		w.encoder = encoder
+		w.compressed = true
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+	if len(w.buffer) == 0 {
+		return nil
+	}
+
+	var err error
+	if w.compressed {
+		_, err = w.encoder.Write(w.buffer)
+	} else {
+		_, err = w.ResponseWriter.Write(w.buffer)
+	}
+	w.buffer = nil
+	return err
+}
+
+// shouldCompress checks whether the response headers and status allow compression
+func shouldCompress(header http.Header, status int) bool {
+	if header.Get("Content-Encoding") != "" {
+		return false // Already encoded by the handler
+	}
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusNotModified {
+		return false
+	}
+
+	contentType := header.Get("Content-Type")
+	if contentType == "" {
+		return true // Handlers in this API write JSON by default
+	}
+	contentType = strings.ToLower(contentType)
+	return strings.HasPrefix(contentType, "text/") ||
+		strings.Contains(contentType, "json") ||
+		strings.Contains(contentType, "javascript") ||
+		strings.Contains(contentType, "xml")
+}
+
+// newEncoder creates the compressor for the negotiated encoding
+func newEncoder(encoding string, w io.Writer) (io.WriteCloser, error) {
+	switch encoding {
+	case encodingBrotli:
+		return brotli.NewWriterLevel(w, brotli.DefaultCompression), nil
+	case encodingZstd:
+		return zstd.NewWriter(w, zstd.WithEncoderLevel(zstd.SpeedDefault))
+	default:
+		return gzip.NewWriterLevel(w, gzip.DefaultCompression)
+	}
+}
+
+// negotiateEncoding picks the best encoding accepted by the client, honouring q-values
+func negotiateEncoding(acceptEncoding string) string {
+	if acceptEncoding == "" {
+		return ""
+	}
+
+	weights := make(map[string]float64)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		name := strings.ToLower(strings.TrimSpace(fields[0]))
+		if name == "" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		weights[name] = quality
+	}
+
+	best := ""
+	bestQuality := 0.0
+	for _, encoding := range serverEncodings {
+		quality, ok := weights[encoding]
+		if !ok {
+			quality, ok = weights["*"]
+		}
+		// Ties are resolved by server preference, so only strictly better values win
+		if ok && quality > bestQuality {
+			best = encoding
+			bestQuality = quality
+		}
+	}
+	return best
+}
+
+// cacheableRequest checks whether a response may be shared through the compressed cache.
+// Only anonymous public GETs qualify: the cache is consulted before RequireRole runs on
+// the admin routes, and authenticated responses must never be replayed to other callers.
+func cacheableRequest(r *http.Request) bool {
+	if r.Method != http.MethodGet || strings.HasPrefix(r.URL.Path, "/admin") {
+		return false
+	}
+	for _, name := range credentialHeaders {
+		if r.Header.Get(name) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// handlerHeaders returns the headers added or changed since initial, leaving out the
+// ones describing the encoding, which are set again when the entry is replayed
+func handlerHeaders(initial, final http.Header) http.Header {
+	header := make(http.Header)
+	for name, values := range final {
+		if name == "Content-Encoding" || name == "Content-Length" {
+			continue
+		}
+		if slices.Equal(initial[name], values) {
+			continue
+		}
+		header[name] = slices.Clone(values)
+	}
+	return header
+}
+
+// compressedCacheKey builds the cache key for a pre-compressed response
+func compressedCacheKey(r *http.Request, encoding string) string {
+	// The body also depends on the requested language and layout
+	hash := md5.Sum([]byte(r.URL.RequestURI() + "|" + r.Header.Get("Accept-Language") + "|" + r.Header.Get(PrettyPrintHeader)))
+	return "compressed:" + encoding + ":" + hex.EncodeToString(hash[:])
+}
+
+// addVary adds a value to the Vary header if it is not already present
+func addVary(header http.Header, value string) {
+	for _, existing := range header.Values("Vary") {
+		for _, v := range strings.Split(existing, ",") {
+			if strings.EqualFold(strings.TrimSpace(v), value) {
+				return
+			}
+		}
+	}
+	header.Add("Vary", value)
+}
@@ -0,0 +1,246 @@
+package middleware
+
+import (
+	"astrovista-api/cache"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestNegotiateEncoding verifies Accept-Encoding parsing and q-value handling
+func TestNegotiateEncoding(t *testing.T) {
+	testCases := []struct {
+		acceptEncoding string
+		expected       string
+	}{
+		{"", ""},
+		{"identity", ""},
+		{"gzip", "gzip"},
+		{"gzip, deflate, br", "br"},
+		{"gzip;q=1.0, br;q=0.5", "gzip"},
+		{"zstd, gzip", "zstd"},
+		{"br;q=0, gzip", "gzip"},
+		{"*", "br"},
+	}
+
+	for _, tc := range testCases {
+		if got := negotiateEncoding(tc.acceptEncoding); got != tc.expected {
+			t.Errorf("negotiateEncoding(%q) = %q, expected %q", tc.acceptEncoding, got, tc.expected)
+		}
+	}
+}
+
+// TestCompressionMiddleware verifies that large bodies are compressed and small ones are not
+func TestCompressionMiddleware(t *testing.T) {
+	largeBody := `{"explanation":"` + strings.Repeat("nebula ", 500) + `"}`
+
+	handler := Compression(CompressionConfig{MinSize: 1024})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("small") != "" {
+			w.Write([]byte(`{"ok":true}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(largeBody))
+	}))
+
+	t.Run("Large body is compressed", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/apods", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusCreated {
+			t.Errorf("Expected status %d, got %d", http.StatusCreated, rr.Code)
+		}
+		if rr.Header().Get("Content-Encoding") != "gzip" {
+			t.Fatalf("Expected gzip encoding, got %q", rr.Header().Get("Content-Encoding"))
+		}
+		if rr.Header().Get("Vary") != "Accept-Encoding" {
+			t.Errorf("Expected Vary: Accept-Encoding, got %q", rr.Header().Get("Vary"))
+		}
+
+		reader, err := gzip.NewReader(rr.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := io.ReadAll(reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(body) != largeBody {
+			t.Errorf("Decompressed body does not match the original")
+		}
+	})
+
+	t.Run("Small body is not compressed", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/apods?small=1", nil)
+		req.Header.Set("Accept-Encoding", "gzip, br")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		if rr.Header().Get("Content-Encoding") != "" {
+			t.Errorf("Small body should not be compressed")
+		}
+		if rr.Body.String() != `{"ok":true}` {
+			t.Errorf("Unexpected body: %s", rr.Body.String())
+		}
+		if rr.Header().Get("Vary") != "Accept-Encoding" {
+			t.Errorf("Vary header should be set even when not compressing")
+		}
+	})
+}
+
+// TestCompressionCache verifies that compressed responses are served from the injected cache
+func TestCompressionCache(t *testing.T) {
+	largeBody := `{"explanation":"` + strings.Repeat("galaxy ", 500) + `"}`
+	calls := 0
+	config := CompressionConfig{MinSize: 1024, CacheTTL: time.Minute, Cache: cache.NewMemoryCache(10)}
+	handler := Compression(config)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(largeBody))
+	}))
+
+	for i, expected := range []string{"", "HIT"} {
+		req := httptest.NewRequest("GET", "/apods", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		if rr.Header().Get("X-Cache") != expected {
+			t.Errorf("Request %d: expected X-Cache %q, got %q", i+1, expected, rr.Header().Get("X-Cache"))
+		}
+		reader, err := gzip.NewReader(rr.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := io.ReadAll(reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(body) != largeBody {
+			t.Errorf("Request %d: decompressed body does not match the original", i+1)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected the second request to be served from the cache, got %d calls", calls)
+	}
+}
+
+// TestCompressionCacheSkipsAuthenticatedRequests verifies that a response to an authenticated
+// request is never stored, so it can't be replayed to an anonymous caller
+func TestCompressionCacheSkipsAuthenticatedRequests(t *testing.T) {
+	config := CompressionConfig{MinSize: 1024, CacheTTL: time.Minute, Cache: cache.NewMemoryCache(10)}
+	handler := Compression(config)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		body := "public "
+		if r.Header.Get("Authorization") != "" || r.Header.Get(APIKeyHeader) != "" || r.Header.Get("X-API-Token") != "" {
+			body = "secret "
+		}
+		w.Write([]byte(`{"data":"` + strings.Repeat(body, 500) + `"}`))
+	}))
+
+	testCases := []struct {
+		name   string
+		path   string
+		header string
+		value  string
+	}{
+		{"Bearer token", "/apods?page=1", "Authorization", "Bearer token"},
+		{"API key", "/apods?page=2", APIKeyHeader, "key"},
+		{"Internal token", "/apods?page=3", "X-API-Token", "token"},
+		{"Admin route", "/admin/keys", "", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for i := 0; i < 2; i++ {
+				req := httptest.NewRequest("GET", tc.path, nil)
+				req.Header.Set("Accept-Encoding", "gzip")
+				if tc.header != "" {
+					req.Header.Set(tc.header, tc.value)
+				}
+				rr := httptest.NewRecorder()
+				handler.ServeHTTP(rr, req)
+				if rr.Header().Get("X-Cache") != "" {
+					t.Fatalf("Request %d with credentials was served from the cache", i+1)
+				}
+			}
+
+			// An anonymous request to the same URL must reach the handler
+			req := httptest.NewRequest("GET", tc.path, nil)
+			req.Header.Set("Accept-Encoding", "gzip")
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			if rr.Header().Get("X-Cache") == "HIT" {
+				t.Fatalf("Anonymous request was served a cached authenticated response")
+			}
+			reader, err := gzip.NewReader(rr.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, _ := io.ReadAll(reader)
+			if strings.Contains(string(body), "secret") {
+				t.Errorf("Anonymous request received an authenticated response")
+			}
+		})
+	}
+}
+
+// TestCompressionCacheHeaders verifies that handler headers are replayed on hits, while
+// partial translations and headers set before the middleware are not cached
+func TestCompressionCacheHeaders(t *testing.T) {
+	config := CompressionConfig{MinSize: 1024, CacheTTL: time.Minute, Cache: cache.NewMemoryCache(10)}
+	compression := Compression(config)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Language", "pt-BR")
+		if r.URL.Query().Get("partial") != "" {
+			w.Header().Set(partialTranslationHeader, "true")
+		}
+		w.Write([]byte(`{"data":"` + strings.Repeat("estrela ", 500) + `"}`))
+	}))
+	quota := 10
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		quota--
+		w.Header().Set("X-Quota-Daily-Remaining", strconv.Itoa(quota))
+		compression.ServeHTTP(w, r)
+	})
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	serve("/apods")
+	rr := serve("/apods")
+	if rr.Header().Get("X-Cache") != "HIT" {
+		t.Fatalf("Expected the second request to be a cache hit")
+	}
+	if rr.Header().Get("Content-Language") != "pt-BR" {
+		t.Errorf("Expected Content-Language to be replayed, got %q", rr.Header().Get("Content-Language"))
+	}
+	if rr.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("Expected Content-Type to be replayed, got %q", rr.Header().Get("Content-Type"))
+	}
+	if rr.Header().Get("X-Quota-Daily-Remaining") != "8" {
+		t.Errorf("Expected the quota header of the current request, got %q", rr.Header().Get("X-Quota-Daily-Remaining"))
+	}
+
+	serve("/apods?partial=1")
+	rr = serve("/apods?partial=1")
+	if rr.Header().Get("X-Cache") != "" {
+		t.Errorf("Partial translations should not be cached")
+	}
+	if rr.Header().Get(partialTranslationHeader) != "true" {
+		t.Errorf("Expected %s to be set", partialTranslationHeader)
+	}
+}
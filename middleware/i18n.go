@@ -1,7 +1,9 @@
 package middleware
 
 import (
+	"astrovista-api/i18n"
 	"context"
+	"fmt"
 	"net/http"
 	"strings"
 )
@@ -9,25 +11,27 @@ import (
 // Context key to store the language
 type langKey struct{}
 
-// LanguageDetector is a middleware that detects the user's preferred language
+// LanguageDetector is a middleware that negotiates the response language. An explicit
+// 'lang' query parameter takes precedence over the Accept-Language header and must name
+// a supported language; the header is matched against the supported languages using its
+// q-values, falling back to English.
 func LanguageDetector(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Get language from Accept-Language header
-		acceptLang := r.Header.Get("Accept-Language")
-
-		// Get language from 'lang' query string (takes precedence over header)
-		queryLang := r.URL.Query().Get("lang")
-		if queryLang != "" {
-			acceptLang = queryLang
+		var lang string
+		if queryLang := r.URL.Query().Get("lang"); queryLang != "" {
+			parsed, err := i18n.ParseLanguage(queryLang)
+			if err != nil {
+				details := fmt.Sprintf("%v (supported: %s)", err, strings.Join(i18n.SupportedLanguages(), ", "))
+				writeError(w, r, http.StatusBadRequest, i18n.CodeUnsupportedLanguage, details)
+				return
+			}
+			lang = parsed
+		} else {
+			lang = i18n.MatchAcceptLanguage(r.Header.Get("Accept-Language"))
 		}
 
-		// Extract the main language code (e.g., "pt-BR" -> "pt")
-		lang := "en" // default
-		if acceptLang != "" {
-			parts := strings.Split(acceptLang, ",")
-			langParts := strings.Split(parts[0], ";") // Remove q-factor
-			lang = strings.TrimSpace(langParts[0])
-		}
+		w.Header().Set("Content-Language", lang)
+		addVary(w.Header(), "Accept-Language")
 
 		// Store the language in the request context
 		ctx := context.WithValue(r.Context(), langKey{}, lang)
@@ -45,3 +49,17 @@ func GetLanguageFromContext(ctx context.Context) string {
 	}
 	return lang
 }
+
+// requestLanguage returns the language of the request. Middleware running before
+// LanguageDetector negotiates it from the Accept-Language header.
+func requestLanguage(r *http.Request) string {
+	if lang, ok := r.Context().Value(langKey{}).(string); ok {
+		return lang
+	}
+	return i18n.MatchAcceptLanguage(r.Header.Get("Accept-Language"))
+}
+
+// writeError writes an error response localized to the language of the request
+func writeError(w http.ResponseWriter, r *http.Request, status int, code i18n.ErrorCode, details string) {
+	i18n.WriteError(w, requestLanguage(r), status, code, details)
+}
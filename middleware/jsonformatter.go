@@ -5,59 +5,144 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"strings"
 )
 
-// JSONResponseWriter is a wrapper for http.ResponseWriter that formats JSON
+// PrettyPrintHeader is the request header that enables indented JSON output
+const PrettyPrintHeader = "X-Pretty-Print"
+
+// JSONResponseWriter is a wrapper for http.ResponseWriter that indents JSON responses.
+// Only JSON bodies are buffered; anything else is passed straight through.
 type JSONResponseWriter struct {
 	http.ResponseWriter
-	Buffer *bytes.Buffer
+	Buffer      *bytes.Buffer
+	status      int
+	decided     bool // Whether we already know if the body will be buffered
+	buffering   bool // Whether the body is being buffered for indentation
+	wroteHeader bool // Whether the status line was already sent to the client
+}
+
+// WriteHeader records the status code for buffered responses or sends it directly otherwise
+func (w *JSONResponseWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.status = code
+	w.decide()
+	if !w.buffering {
+		w.wroteHeader = true
+		w.ResponseWriter.WriteHeader(code)
+	}
 }
 
-// Write captures the written response
+// Write captures JSON responses and passes everything else through
 func (w *JSONResponseWriter) Write(b []byte) (int, error) {
-	return w.Buffer.Write(b)
+	if !w.decided {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.buffering {
+		return w.Buffer.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush switches to pass-through mode so streamed responses are never held back
+func (w *JSONResponseWriter) Flush() {
+	// A flush before the first write commits the headers, so the body can't be buffered anymore
+	w.decide()
+	if w.buffering {
+		w.buffering = false
+		w.flushBuffer(w.Buffer.Bytes())
+		w.Buffer.Reset()
+	} else if !w.wroteHeader {
+		w.wroteHeader = true
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// decide checks the Content-Type once headers are final to choose between buffering and pass-through
+func (w *JSONResponseWriter) decide() {
+	if w.decided {
+		return
+	}
+	w.decided = true
+	contentType := w.Header().Get("Content-Type")
+	// If empty, we assume JSON
+	w.buffering = contentType == "" || strings.HasPrefix(contentType, "application/json")
+}
+
+// flushBuffer sends the status line (if still pending) followed by the given body
+func (w *JSONResponseWriter) flushBuffer(body []byte) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+	if len(body) > 0 {
+		w.ResponseWriter.Write(body)
+	}
+}
+
+// finish indents the buffered JSON and writes it to the client
+func (w *JSONResponseWriter) finish() {
+	if !w.buffering {
+		return
+	}
+
+	body := w.Buffer.Bytes()
+	if len(body) > 0 {
+		// json.Indent keeps the original key order, unlike decoding into interface{}
+		var formatted bytes.Buffer
+		if err := json.Indent(&formatted, body, "", "    "); err == nil {
+			// Set Content-Type if not already defined
+			if w.Header().Get("Content-Type") == "" {
+				w.Header().Set("Content-Type", "application/json")
+			}
+			body = formatted.Bytes()
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	}
+
+	// If not JSON or can't format, the original buffer is written
+	w.flushBuffer(body)
 }
 
-// JSONFormatterMiddleware ensures that all JSON responses are properly formatted
+// JSONFormatterMiddleware indents JSON responses when the client asks for pretty output
+// via ?pretty=true or the X-Pretty-Print header. Compact JSON is returned otherwise.
 func JSONFormatterMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Initialize buffer and wrapper
-		buffer := &bytes.Buffer{}
+		// The body layout depends on the pretty printing header
+		addVary(w.Header(), PrettyPrintHeader)
+
+		if !wantsPrettyJSON(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
 		wrapper := &JSONResponseWriter{
 			ResponseWriter: w,
-			Buffer:         buffer,
+			Buffer:         &bytes.Buffer{},
+			status:         http.StatusOK,
 		}
 
 		// Execute the handler with our wrapper
 		next.ServeHTTP(wrapper, r)
 
-		// Check if content type is JSON
-		contentType := w.Header().Get("Content-Type")
-		isJSON := contentType == "application/json" || contentType == "" // If empty, we assume JSON
-
-		if isJSON && buffer.Len() > 0 {
-			var data interface{}
-
-			// Try to decode JSON from buffer
-			if err := json.Unmarshal(buffer.Bytes(), &data); err == nil {
-				// Reencode with pretty formatting
-				formattedJSON, err := json.MarshalIndent(data, "", "    ")
-				if err == nil {
-					// Set Content-Type if not already defined
-					if contentType == "" {
-						w.Header().Set("Content-Type", "application/json")
-					}
-					// Write content length header
-					w.Header().Set("Content-Length", strconv.Itoa(len(formattedJSON)))
-
-					// Write formatted JSON
-					w.Write(formattedJSON)
-					return
-				}
-			}
+		// Handlers that never wrote anything still get their status sent
+		if !wrapper.decided {
+			wrapper.WriteHeader(http.StatusOK)
 		}
-
-		// If not JSON or can't format, write original buffer
-		w.Write(buffer.Bytes())
+		wrapper.finish()
 	})
 }
+
+// wantsPrettyJSON checks the query string and headers for a pretty printing request
+func wantsPrettyJSON(r *http.Request) bool {
+	if pretty, err := strconv.ParseBool(r.URL.Query().Get("pretty")); err == nil {
+		return pretty
+	}
+	pretty, err := strconv.ParseBool(r.Header.Get(PrettyPrintHeader))
+	return err == nil && pretty
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestJSONFormatterMiddleware verifies compact output by default and opt-in pretty printing
+func TestJSONFormatterMiddleware(t *testing.T) {
+	handler := JSONFormatterMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/text" {
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte("plain"))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"title":"Andromeda","date":"2023-01-15"}`))
+	}))
+
+	testCases := []struct {
+		name     string
+		target   string
+		header   string
+		expected string
+		status   int
+	}{
+		{"Compact by default", "/apod", "", `{"title":"Andromeda","date":"2023-01-15"}`, http.StatusNotFound},
+		{"Pretty query parameter", "/apod?pretty=true", "", "{\n    \"title\": \"Andromeda\",\n    \"date\": \"2023-01-15\"\n}", http.StatusNotFound},
+		{"Pretty header", "/apod", "true", "{\n    \"title\": \"Andromeda\",\n    \"date\": \"2023-01-15\"\n}", http.StatusNotFound},
+		{"Non-JSON passes through", "/text?pretty=true", "", "plain", http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tc.target, nil)
+			if tc.header != "" {
+				req.Header.Set(PrettyPrintHeader, tc.header)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tc.status {
+				t.Errorf("Expected status %d, got %d", tc.status, rr.Code)
+			}
+			if rr.Body.String() != tc.expected {
+				t.Errorf("Expected body %q, got %q", tc.expected, rr.Body.String())
+			}
+		})
+	}
+}
+
+// TestJSONFormatterFlushBeforeWrite verifies that a flush before the first write sends the
+// headers and streams the body unchanged instead of buffering it after the headers went out
+func TestJSONFormatterFlushBeforeWrite(t *testing.T) {
+	handler := JSONFormatterMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.(http.Flusher).Flush()
+		w.Write([]byte(`{"status":"processing"}`))
+	}))
+
+	req := httptest.NewRequest("GET", "/apod?pretty=true", nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+	if !rr.Flushed {
+		t.Error("Expected the response to be flushed")
+	}
+	if rr.Body.String() != `{"status":"processing"}` {
+		t.Errorf("Expected the body to be passed through, got %q", rr.Body.String())
+	}
+	if contentLength := rr.Header().Get("Content-Length"); contentLength != "" {
+		t.Errorf("Expected no Content-Length on a streamed response, got %q", contentLength)
+	}
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"astrovista-api/auth"
+	"astrovista-api/i18n"
+	"context"
+	"net/http"
+	"strings"
+)
+
+// JWTAuth returns a middleware that authenticates "Authorization: Bearer" tokens and stores
+// the resulting Principal in the request context. Requests without a token pass through
+// unchanged, so API keys and anonymous access keep working.
+func JWTAuth(verifier *auth.Verifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorization := r.Header.Get("Authorization")
+			scheme, token, found := strings.Cut(authorization, " ")
+			if !found || !strings.EqualFold(scheme, "Bearer") {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			identity, err := verifier.Verify(strings.TrimSpace(token))
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+				writeError(w, r, http.StatusUnauthorized, i18n.CodeUnauthorized, err.Error())
+				return
+			}
+
+			principal := &Principal{
+				Subject: "jwt:" + identity.Subject,
+				Name:    identity.Subject,
+				Roles:   identity.Roles,
+			}
+			ctx := context.WithValue(r.Context(), principalKey{}, principal)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
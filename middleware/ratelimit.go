@@ -1,42 +1,121 @@
 package middleware
 
 import (
-	"encoding/json"
+	"astrovista-api/i18n"
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"net"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
 
-// RateLimiter implements a simple IP-based request counter with sliding window
+// RateLimitPolicy describes how many requests a client may make on a route
+type RateLimitPolicy struct {
+	Name   string        // Identifies the policy in storage keys (e.g. "post-apod")
+	Limit  int           // Maximum number of requests per window
+	Window time.Duration // Time window for counting
+	Burst  int           // Requests allowed back to back (defaults to Limit)
+}
+
+// capacity returns the bucket size of the policy
+func (p RateLimitPolicy) capacity() int {
+	if p.Burst > 0 {
+		return p.Burst
+	}
+	return p.Limit
+}
+
+// emissionInterval returns the time it takes to regain one request
+func (p RateLimitPolicy) emissionInterval() time.Duration {
+	return p.Window / time.Duration(p.Limit)
+}
+
+// ParseRateLimitPolicy parses a policy in the "<limit>/<window>" format (e.g. "60/1m")
+func ParseRateLimitPolicy(name, value string) (RateLimitPolicy, error) {
+	parts := strings.SplitN(value, "/", 2)
+	if len(parts) != 2 {
+		return RateLimitPolicy{}, fmt.Errorf("invalid rate limit %q: expected <limit>/<window>", value)
+	}
+	limit, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil || limit < 1 {
+		return RateLimitPolicy{}, fmt.Errorf("invalid rate limit %q: limit must be a positive integer", value)
+	}
+	window, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+	if err != nil || window <= 0 {
+		return RateLimitPolicy{}, fmt.Errorf("invalid rate limit %q: window must be a positive duration", value)
+	}
+	return RateLimitPolicy{Name: name, Limit: limit, Window: window}, nil
+}
+
+// RateLimitResult is the outcome of a rate limit check
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	ResetAfter time.Duration // Time until the bucket is full again
+	RetryAfter time.Duration // Time until the next request is allowed (when denied)
+}
+
+// Limiter is the storage backend used by RateLimiter
+type Limiter interface {
+	// Allow records a request for key under the given policy and reports whether it is allowed
+	Allow(ctx context.Context, key string, policy RateLimitPolicy) (RateLimitResult, error)
+}
+
+// RateLimiter is a middleware that applies a rate limit policy using a pluggable Limiter
 type RateLimiter struct {
-	mutex    sync.Mutex
-	requests map[string][]time.Time
-	limit    int           // Maximum number of requests
-	window   time.Duration // Time window for counting
+	limiter Limiter
+	policy  RateLimitPolicy
 }
 
-// NewRateLimiter creates a new rate limiter with specific limits
+// NewRateLimiter creates a new in-memory rate limiter with specific limits
 func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
+	return NewRateLimiterWithBackend(NewMemoryLimiter(), RateLimitPolicy{
+		Name:   "default",
+		Limit:  limit,
+		Window: window,
+	})
+}
+
+// NewRateLimiterWithBackend creates a rate limiter for a policy using the given backend
+func NewRateLimiterWithBackend(limiter Limiter, policy RateLimitPolicy) *RateLimiter {
 	return &RateLimiter{
-		requests: make(map[string][]time.Time),
-		limit:    limit,
-		window:   window,
+		limiter: limiter,
+		policy:  policy,
 	}
 }
 
-// Limit is a middleware that limits requests by IP
+// WithPolicy returns a rate limiter sharing the same backend with a different policy,
+// which allows different limits per route
+func (rl *RateLimiter) WithPolicy(policy RateLimitPolicy) *RateLimiter {
+	return NewRateLimiterWithBackend(rl.limiter, policy)
+}
+
+// Limit is a middleware that limits requests by client
 func (rl *RateLimiter) Limit(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Get the client's IP (or you can use a custom identifier)
-		ip := r.RemoteAddr
-		// Check if the IP is within the rate limits
-		if !rl.isAllowed(ip) {
-			w.Header().Set("Content-Type", "application/json")
-			w.Header().Set("Retry-After", "60")       // Suggest trying again after 60 seconds
-			w.WriteHeader(http.StatusTooManyRequests) // 429 Too Many Requests
-			json.NewEncoder(w).Encode(map[string]string{
-				"error": "Rate limit exceeded. Please try again later.",
-			})
+		key := rl.policy.Name + ":" + clientKey(r)
+
+		result, err := rl.limiter.Allow(r.Context(), key, rl.policy)
+		if err != nil {
+			// Fail open: an unavailable backend should not take the API down
+			log.Printf("Error checking rate limit: %v", err)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+		w.Header().Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+
+		if !result.Allowed {
+			w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			writeError(w, r, http.StatusTooManyRequests, i18n.CodeRateLimitExceeded, "") // 429 Too Many Requests
 			return
 		}
 
@@ -45,30 +124,92 @@ func (rl *RateLimiter) Limit(next http.Handler) http.Handler {
 	})
 }
 
-// isAllowed checks if the IP can make more requests
-func (rl *RateLimiter) isAllowed(ip string) bool {
-	rl.mutex.Lock()
-	defer rl.mutex.Unlock()
+// clientKey identifies the client making the request
+func clientKey(r *http.Request) string {
+	// Prefer the client resolved behind trusted proxies
+	if key := GetClientKeyFromContext(r.Context()); key != "" {
+		return key
+	}
+	// RemoteAddr includes the port, which changes for every connection
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// ceilSeconds rounds a duration up to whole seconds for HTTP headers
+func ceilSeconds(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+	return int(math.Ceil(d.Seconds()))
+}
+
+// gcra applies the Generic Cell Rate Algorithm (a token bucket variant that only needs
+// to store one timestamp per client): tat is the theoretical arrival time of the next request
+func gcra(now, tat time.Time, policy RateLimitPolicy) (RateLimitResult, time.Time) {
+	emission := policy.emissionInterval()
+	tolerance := emission * time.Duration(policy.capacity())
+
+	if tat.Before(now) {
+		tat = now
+	}
+	newTat := tat.Add(emission)
+	allowAt := newTat.Add(-tolerance)
+
+	result := RateLimitResult{Limit: policy.Limit}
+	if allowAt.After(now) {
+		result.ResetAfter = tat.Sub(now)
+		result.RetryAfter = allowAt.Sub(now)
+		return result, tat
+	}
+
+	result.Allowed = true
+	result.Remaining = int((tolerance - newTat.Sub(now)) / emission)
+	result.ResetAfter = newTat.Sub(now)
+	return result, newTat
+}
+
+// MemoryLimiter is a per-process Limiter, suitable for single instance deployments
+type MemoryLimiter struct {
+	mutex     sync.Mutex
+	tats      map[string]time.Time
+	lastSweep time.Time
+}
+
+// NewMemoryLimiter creates a new in-memory limiter
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{
+		tats:      make(map[string]time.Time),
+		lastSweep: time.Now(),
+	}
+}
+
+// Allow implements the Limiter interface
+func (m *MemoryLimiter) Allow(ctx context.Context, key string, policy RateLimitPolicy) (RateLimitResult, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
 
 	now := time.Now()
-	windowStart := now.Add(-rl.window)
-
-	// Remove old timestamps outside the time window
-	if timestamps, exists := rl.requests[ip]; exists {
-		var validTimestamps []time.Time
-		for _, ts := range timestamps {
-			if ts.After(windowStart) {
-				validTimestamps = append(validTimestamps, ts)
-			}
-		}
-		rl.requests[ip] = validTimestamps
-		// Check if the limit has been reached
-		if len(validTimestamps) >= rl.limit {
-			return false
-		}
+	m.sweepLocked(now)
+
+	result, tat := gcra(now, m.tats[key], policy)
+	m.tats[key] = tat
+	return result, nil
+}
+
+// sweepLocked removes clients whose bucket is full again, so the map does not grow
+// without bound (assumes the lock is already obtained)
+func (m *MemoryLimiter) sweepLocked(now time.Time) {
+	if now.Sub(m.lastSweep) < time.Minute {
+		return
 	}
+	m.lastSweep = now
 
-	// Add the new request to the counter
-	rl.requests[ip] = append(rl.requests[ip], now)
-	return true
+	for key, tat := range m.tats {
+		if tat.Before(now) {
+			delete(m.tats, key)
+		}
+	}
 }
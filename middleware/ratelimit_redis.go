@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// gcraScript runs the GCRA check atomically in Redis. It uses the Redis clock so that
+// all replicas agree on the current time. Times are in milliseconds.
+var gcraScript = redis.NewScript(`
+redis.replicate_commands()
+local emission = tonumber(ARGV[1])
+local tolerance = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local tat = tonumber(redis.call('GET', KEYS[1]))
+if not tat or tat < now then
+	tat = now
+end
+
+local new_tat = tat + emission
+local allow_at = new_tat - tolerance
+if allow_at > now then
+	return {0, 0, tat - now, allow_at - now}
+end
+
+redis.call('SET', KEYS[1], new_tat, 'PX', new_tat - now)
+return {1, math.floor((tolerance - (new_tat - now)) / emission), new_tat - now, 0}
+`)
+
+// RedisLimiter is a Limiter shared by all API replicas through Redis
+type RedisLimiter struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisLimiter creates a new Redis-backed limiter
+func NewRedisLimiter(client *redis.Client) *RedisLimiter {
+	return &RedisLimiter{
+		client: client,
+		prefix: "ratelimit:",
+	}
+}
+
+// Allow implements the Limiter interface
+func (l *RedisLimiter) Allow(ctx context.Context, key string, policy RateLimitPolicy) (RateLimitResult, error) {
+	emission := policy.emissionInterval().Milliseconds()
+	if emission < 1 {
+		emission = 1
+	}
+	tolerance := emission * int64(policy.capacity())
+
+	values, err := gcraScript.Run(ctx, l.client, []string{l.prefix + key}, emission, tolerance).Int64Slice()
+	if err != nil {
+		return RateLimitResult{}, err
+	}
+
+	return RateLimitResult{
+		Allowed:    values[0] == 1,
+		Limit:      policy.Limit,
+		Remaining:  int(values[1]),
+		ResetAfter: time.Duration(values[2]) * time.Millisecond,
+		RetryAfter: time.Duration(values[3]) * time.Millisecond,
+	}, nil
+}
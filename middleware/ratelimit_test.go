@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestGCRA verifies the token bucket behaviour of the rate limit algorithm
+func TestGCRA(t *testing.T) {
+	policy := RateLimitPolicy{Name: "test", Limit: 3, Window: 3 * time.Second}
+	now := time.Now()
+	var tat time.Time
+
+	// The whole bucket can be used at once
+	for i := 0; i < 3; i++ {
+		var result RateLimitResult
+		result, tat = gcra(now, tat, policy)
+		if !result.Allowed {
+			t.Fatalf("Request %d should be allowed", i+1)
+		}
+		if result.Remaining != 2-i {
+			t.Errorf("Request %d: expected %d remaining, got %d", i+1, 2-i, result.Remaining)
+		}
+	}
+
+	// The next request is denied until one token is regained
+	result, tat := gcra(now, tat, policy)
+	if result.Allowed {
+		t.Fatalf("Request over the limit should be denied")
+	}
+	if result.RetryAfter != time.Second {
+		t.Errorf("Expected retry after 1s, got %v", result.RetryAfter)
+	}
+
+	result, _ = gcra(now.Add(time.Second), tat, policy)
+	if !result.Allowed {
+		t.Errorf("Request should be allowed after the emission interval")
+	}
+}
+
+// TestRateLimiterHeaders verifies the RateLimit headers and the 429 response
+func TestRateLimiterHeaders(t *testing.T) {
+	rateLimiter := NewRateLimiter(1, time.Minute)
+	handler := rateLimiter.Limit(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// Different ports of the same host share the limit
+	for i, remoteAddr := range []string{"10.0.0.1:5000", "10.0.0.1:5001"} {
+		req := httptest.NewRequest("POST", "/apod", nil)
+		req.RemoteAddr = remoteAddr
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		if rr.Header().Get("RateLimit-Limit") != "1" {
+			t.Errorf("Expected RateLimit-Limit 1, got %q", rr.Header().Get("RateLimit-Limit"))
+		}
+		if i == 0 && rr.Code != http.StatusOK {
+			t.Errorf("First request should be allowed, got %d", rr.Code)
+		}
+		if i == 1 {
+			if rr.Code != http.StatusTooManyRequests {
+				t.Errorf("Second request should be limited, got %d", rr.Code)
+			}
+			if rr.Header().Get("Retry-After") != "60" {
+				t.Errorf("Expected Retry-After 60, got %q", rr.Header().Get("Retry-After"))
+			}
+		}
+	}
+}